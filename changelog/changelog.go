@@ -0,0 +1,123 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// Output formats.
+const (
+	ReleaseFormat   = "release"
+	ChangelogFormat = "changelog"
+)
+
+// pullRequestRe matches the PR number in GitHub merge and squash commit subjects.
+var pullRequestRe = regexp.MustCompile(`(?:^Merge pull request #(\d+) |\(#(\d+)\)$)`)
+
+// releaseHeadings are the GitHub release section headings for each issue category.
+var releaseHeadings = map[string]string{
+	issues.StoryCategory: "Features",
+	issues.BugCategory:   "Bug Fixes",
+	issues.TaskCategory:  "Tasks",
+}
+
+// changelogHeadings are the Keep a Changelog section headings for each issue category.
+// Reference: https://keepachangelog.com/en/1.0.0/
+var changelogHeadings = map[string]string{
+	issues.StoryCategory: "Added",
+	issues.BugCategory:   "Fixed",
+	issues.TaskCategory:  "Changed",
+}
+
+// Entry is a single line of the changelog.
+type Entry struct {
+	Issue        issues.Issue
+	PullRequests []string
+}
+
+// Notes contains everything needed to render the changelog.
+type Notes struct {
+	Version string
+	Date    time.Time
+	Entries []Entry
+}
+
+// PullRequestsByIssue maps issue IDs to the PR numbers found in the commit subjects.
+func PullRequestsByIssue(subjects []string) map[string][]string {
+	result := make(map[string][]string)
+	for _, subject := range subjects {
+		m := pullRequestRe.FindStringSubmatch(subject)
+		if m == nil {
+			continue
+		}
+		ID := issues.ParseIDFromCommit(subject)
+		if ID == "" {
+			continue
+		}
+		number := m[1] + m[2]
+		result[ID] = append(result[ID], number)
+	}
+	return result
+}
+
+// NewNotes creates the release notes for the issues, linking the PRs found in the commit subjects.
+func NewNotes(version string, list []issues.Issue, subjects []string) Notes {
+	prs := PullRequestsByIssue(subjects)
+	notes := Notes{Version: version, Date: time.Now()}
+	for _, i := range list {
+		notes.Entries = append(notes.Entries, Entry{
+			Issue:        i,
+			PullRequests: prs[strings.ToUpper(i.ID)],
+		})
+	}
+	return notes
+}
+
+// Markdown renders the notes in the given format.
+func (n Notes) Markdown(format string) (string, error) {
+	var headings map[string]string
+	var b strings.Builder
+
+	switch format {
+	case ReleaseFormat:
+		headings = releaseHeadings
+	case ChangelogFormat:
+		headings = changelogHeadings
+		fmt.Fprintf(&b, "## [%s] - %s\n\n", n.Version, n.Date.Format("2006-01-02"))
+	default:
+		return "", fmt.Errorf("unknown format %q. expected %s or %s", format, ReleaseFormat, ChangelogFormat)
+	}
+
+	sectionLevel := "##"
+	if format == ChangelogFormat {
+		sectionLevel = "###"
+	}
+
+	for _, category := range issues.Categories {
+		var lines []string
+		for _, e := range n.Entries {
+			if e.Issue.Category() == category {
+				lines = append(lines, e.line())
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n\n%s\n\n", sectionLevel, headings[category], strings.Join(lines, "\n"))
+	}
+
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// line renders the entry as a markdown list item.
+func (e Entry) line() string {
+	s := fmt.Sprintf("- [%s](%s): %s", e.Issue.ID, e.Issue.WebURL, e.Issue.Title)
+	for _, number := range e.PullRequests {
+		s += fmt.Sprintf(" (#%s)", number)
+	}
+	return s
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/github"
+)
+
+// checksCmd represents the checks command.
+var checksCmd = &cobra.Command{
+	Use:    "checks",
+	Short:  "Show the CI status of the pull request for the current branch",
+	PreRun: preRunChecksCmd,
+	Run:    runChecksCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(checksCmd)
+	checksCmd.Flags().BoolP("watch", "w", false, "poll the checks until they complete")
+	checksCmd.Flags().Duration("interval", 10*time.Second, "time between polls in watch mode")
+	checksCmd.Flags().Duration("grace", 2*time.Minute, "how long watch mode waits for the first checks to be reported")
+	checksCmd.Flags().BoolP("notify", "n", false, "show a desktop notification when the checks complete")
+}
+
+func preRunChecksCmd(_ *cobra.Command, _ []string) {
+	requireGitHubAccess()
+}
+
+func runChecksCmd(cmd *cobra.Command, _ []string) {
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	grace, _ := cmd.Flags().GetDuration("grace")
+	notifyDone, _ := cmd.Flags().GetBool("notify")
+
+	repo, pr := findCurrentPullRequest()
+	client := github.NewClient(config.GitHub)
+
+	// CI may take a while to report the first checks of a new commit.
+	graceEnd := time.Now().Add(grace)
+	var state string
+	for {
+		checks, err := client.Checks(repo, pr.Head.SHA)
+		failIfError(err)
+
+		state = github.ChecksState(checks)
+		displayChecks(pr, checks)
+
+		if !watch || checksDone(state, graceEnd) {
+			break
+		}
+		time.Sleep(interval)
+	}
+
+	if notifyDone {
+		notify(fmt.Sprintf("PR #%d checks: %s", pr.Number, state), pr.Title)
+	}
+	if watch && state == github.CheckNone {
+		failIfError(fmt.Errorf("no checks were reported for pull request #%d within %s", pr.Number, grace))
+	}
+	if state == github.CheckFailure {
+		os.Exit(1)
+	}
+}
+
+// checksDone returns true once the checks have completed,
+// or if no checks were reported before the end of the grace period.
+func checksDone(state string, graceEnd time.Time) bool {
+	switch state {
+	case github.CheckPending:
+		return false
+	case github.CheckNone:
+		return !time.Now().Before(graceEnd)
+	}
+	return true
+}
+
+// displayChecks in a nicely formatted way.
+func displayChecks(pr *github.PullRequestResult, checks []github.Check) {
+	cyan := color.New(color.FgHiCyan).SprintFunc()
+	states := map[string]func(a ...interface{}) string{
+		github.CheckSuccess: color.New(color.FgGreen).SprintFunc(),
+		github.CheckPending: color.New(color.FgYellow).SprintFunc(),
+		github.CheckFailure: color.New(color.FgRed).SprintFunc(),
+	}
+
+	fmt.Println()
+	title(fmt.Sprintf("  Checks for #%d (%s):", pr.Number, time.Now().Format("15:04:05")))
+	if len(checks) == 0 {
+		fmt.Println("    No checks found.")
+	}
+	for _, c := range checks {
+		name := c.Name
+		if c.Required {
+			name += " (required)"
+		}
+		fmt.Printf("    %s %s %s\n", states[c.State](fmt.Sprintf("%-8s", c.State)), name, cyan(c.Duration()))
+		if c.URL != "" {
+			fmt.Println("             " + c.URL)
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/commit"
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// commitEditorHelp is appended to the commit message opened in the editor.
+const commitEditorHelp = `
+# Write the commit message for %s: %s
+# Lines starting with '#' are ignored and an empty message aborts the commit.
+# The subject line can be up to %d characters long.
+`
+
+// commitCmd represents the commit command.
+var commitCmd = &cobra.Command{
+	Use:   "commit [subject]",
+	Short: "Commit the staged changes with a message for the issue of the branch",
+	Long: `Commit the staged changes with a message built from the commit template and the issue of the branch.
+Without a subject, the message is opened in the git editor pre-filled with the issue title.`,
+	Run: runCommitCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(commitCmd)
+	commitCmd.Flags().BoolP("all", "a", false, "stage the changes to tracked files before committing")
+	commitCmd.Flags().StringP("type", "t", "", "commit type to use instead of the one inferred from the issue type")
+}
+
+func runCommitCmd(cmd *cobra.Command, args []string) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	ID := issues.ParseIDFromBranch(branch)
+	if ID == "" {
+		failIfError(fmt.Errorf("no issue ID found in the branch name %q", branch))
+	}
+	issue, err := jira.GetIssue(ID, config.Jira)
+	failIfError(err)
+
+	format, err := config.getStringOrFile(commit.FormatConfigKey, commit.FormatFileConfigKey)
+	failIfError(err)
+
+	data := commit.Data{
+		Issue:   issue,
+		Branch:  branch,
+		Type:    commit.TypeForIssue(issue, config.commitTypes()),
+		Subject: strings.Join(args, " "),
+	}
+	if commitType, _ := cmd.Flags().GetString("type"); commitType != "" {
+		data.Type = commitType
+	}
+
+	maxLength := config.maxCommitSubjectLength()
+	var message string
+	if data.Subject != "" {
+		message, err = commit.Render(format, data)
+		failIfError(err)
+		failIfError(commit.Validate(message, maxLength))
+	} else {
+		data.Subject = issue.Title
+		message, err = commit.Render(format, data)
+		failIfError(err)
+		message = editCommitMessage(message+fmt.Sprintf(commitEditorHelp, issue.ID, issue.Title, maxLength), maxLength)
+	}
+
+	all, _ := cmd.Flags().GetBool("all")
+	out, err := git.Commit(message, all)
+	fmt.Print(out)
+	failIfError(err)
+}
+
+// editCommitMessage opens the message in the editor until it is valid or the user gives up.
+// Exits if the edited message is empty.
+func editCommitMessage(message string, maxLength int) string {
+	for {
+		edited, err := editText(message)
+		failIfError(err)
+
+		cleaned := commit.Clean(edited)
+		if cleaned == "" {
+			failIfError(errors.New("aborting the commit due to an empty message"))
+		}
+		err = commit.Validate(cleaned, maxLength)
+		if err == nil {
+			return cleaned
+		}
+
+		warnIfError(err)
+		if !confirm("Edit the message again") {
+			os.Exit(1)
+		}
+		message = edited
+	}
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path"
 
 	"github.com/spf13/viper"
 
+	"github.com/greganswer/workflow/commit"
 	"github.com/greganswer/workflow/file"
+	"github.com/greganswer/workflow/git"
 	"github.com/greganswer/workflow/github"
 	"github.com/greganswer/workflow/jira"
 )
@@ -17,6 +20,7 @@ type configData struct {
 	Global *viper.Viper
 	Local  *viper.Viper
 	Jira   *jira.Config
+	GitHub *github.Config
 }
 
 // Setting is an individual setting that can be store in a config.
@@ -35,9 +39,9 @@ func (c *configData) init() {
 	c.Global = viper.New()
 	c.Local = viper.New()
 
-	// c.Local.SetConfigFile(
-	// 	path.Join(git.RootDir(), filename),
-	// )
+	c.Local.SetConfigFile(
+		path.Join(git.RootDir(), filename),
+	)
 
 	c.Global.SetConfigFile(
 		path.Join(currentUser.HomeDir, filename),
@@ -50,9 +54,16 @@ func (c *configData) init() {
 		failIfError(v.ReadInConfig())
 	}
 
+	// The local config is optional so it is only read when the project has one.
+	if exists, _ := file.Exists(c.Local.ConfigFileUsed()); exists {
+		failIfError(c.Local.ReadInConfig())
+	}
+
 	failIfError(c.validate())
 	failIfError(c.update())
+	c.initGit()
 	c.initJira()
+	c.initGitHub()
 }
 
 // validate each required setting in the configs.
@@ -117,12 +128,125 @@ func (c *configData) update() error {
 	return nil
 }
 
+// secretConfigKeys are only read from the global config.
+// The local config is committed with the project, so it must not override credentials.
+var secretConfigKeys = map[string]bool{
+	jira.UsernameConfigKey:   true,
+	jira.TokenConfigKey:      true,
+	github.UsernameConfigKey: true,
+	github.TokenConfigKey:    true,
+}
+
+// getString returns the local config value for the key, falling back to the global config.
+// Secret keys are only read from the global config.
+func (c *configData) getString(key string) string {
+	if value := c.Local.GetString(key); value != "" && !secretConfigKeys[key] {
+		return value
+	}
+	return c.Global.GetString(key)
+}
+
+// getStringOrFile returns the contents of the file in the fileKey config, relative to the project root,
+// falling back to the value of the key config.
+func (c *configData) getStringOrFile(key, fileKey string) (string, error) {
+	name := c.getString(fileKey)
+	if name == "" {
+		return c.getString(key), nil
+	}
+	if !path.IsAbs(name) {
+		name = path.Join(git.RootDir(), name)
+	}
+	b, err := ioutil.ReadFile(name)
+	return string(b), err
+}
+
+// prFormat returns the PR title and body templates from the configs.
+func (c *configData) prFormat() (github.PrFormat, error) {
+	var f github.PrFormat
+	var err error
+	if f.Title, err = c.getStringOrFile(github.TitleFormatConfigKey, github.TitleFormatFileConfigKey); err != nil {
+		return f, err
+	}
+	if f.Body, err = c.getStringOrFile(github.BodyFormatConfigKey, github.BodyFormatFileConfigKey); err != nil {
+		return f, err
+	}
+	return f, f.Validate()
+}
+
+// githubLabels returns the table mapping Jira issue types and labels to GitHub labels.
+// Local config entries override global ones.
+func (c *configData) githubLabels() map[string]string {
+	table := c.Global.GetStringMapString(github.LabelsConfigKey)
+	for key, label := range c.Local.GetStringMapString(github.LabelsConfigKey) {
+		table[key] = label
+	}
+	return table
+}
+
+// commitTypes returns the table mapping Jira issue types to commit types.
+// Local config entries override global ones.
+func (c *configData) commitTypes() map[string]string {
+	table := c.Global.GetStringMapString(commit.TypesConfigKey)
+	for key, commitType := range c.Local.GetStringMapString(commit.TypesConfigKey) {
+		table[key] = commitType
+	}
+	return table
+}
+
+// maxCommitSubjectLength returns the maximum length of a commit subject line from the configs.
+func (c *configData) maxCommitSubjectLength() int {
+	for _, v := range []*viper.Viper{c.Local, c.Global} {
+		if v.IsSet(commit.MaxSubjectLengthConfigKey) {
+			return v.GetInt(commit.MaxSubjectLengthConfigKey)
+		}
+	}
+	return commit.DefaultMaxSubjectLength
+}
+
+// initGit from global and local configs.
+func (c *configData) initGit() {
+	if remote := c.getString(git.RemoteConfigKey); remote != "" {
+		git.Remote = remote
+	}
+}
+
 // initJira from global and local configs.
 func (c *configData) initJira() {
 	c.Jira = &jira.Config{
-		Username: c.Global.GetString(jira.UsernameConfigKey),
-		Token:    c.Global.GetString(jira.TokenConfigKey),
-		APIURL:   c.Local.GetString(jira.APIConfigKey),
-		WebURL:   c.Local.GetString(jira.WebConfigKey),
+		Username:       c.Global.GetString(jira.UsernameConfigKey),
+		Token:          c.Global.GetString(jira.TokenConfigKey),
+		APIURL:         c.Local.GetString(jira.APIConfigKey),
+		WebURL:         c.Local.GetString(jira.WebConfigKey),
+		DoneTransition: c.getString(jira.DoneTransitionConfigKey),
 	}
 }
+
+// initGitHub from global and local configs.
+// The GitHub host is detected from the remote URL of the project.
+func (c *configData) initGitHub() {
+	name, err := git.RemoteHost()
+	if err != nil && git.RootDir() != "" {
+		warnIfError(err)
+	}
+	host := github.FindHost(name, c.githubHosts())
+
+	token := host.Token
+	if token == "" {
+		token = c.getString(github.TokenConfigKey)
+	}
+
+	c.GitHub = &github.Config{
+		Username: c.Global.GetString(github.UsernameConfigKey),
+		Token:    token,
+		Host:     host.Name,
+		APIURL:   host.APIURL,
+	}
+}
+
+// githubHosts returns the GitHub hosts from the global config.
+// Hosts contain tokens and API URLs the tokens are sent to, so they are never read from the local config.
+func (c *configData) githubHosts() []github.Host {
+	var hosts []github.Host
+	warnIfError(c.Global.UnmarshalKey(github.HostsConfigKey, &hosts))
+	return hosts
+}
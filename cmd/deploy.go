@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+)
+
+// runLookupTimeout is how long to wait for GitHub to create the dispatched workflow run.
+const runLookupTimeout = 2 * time.Minute
+
+// deployCmd represents the deploy command.
+var deployCmd = &cobra.Command{
+	Use:   "deploy <workflow>",
+	Short: "Run a GitHub Actions workflow on the current branch and follow it",
+	Long: `Dispatch a GitHub Actions workflow, by file name or ID, on the current branch.
+The status of its jobs and the end of their logs are shown until the run completes.`,
+	PreRun: preRunDeployCmd,
+	Args:   validateDeployCmdArgs,
+	Run:    runDeployCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+	deployCmd.Flags().StringArrayP("input", "i", nil, "workflow input in the key=value form. can be repeated")
+	deployCmd.Flags().Duration("interval", 5*time.Second, "time between polls of the run status")
+	deployCmd.Flags().Int("tail", 20, "number of log lines to show for each completed job")
+}
+
+func validateDeployCmdArgs(_ *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("requires the workflow argument")
+	}
+	return nil
+}
+
+func preRunDeployCmd(_ *cobra.Command, _ []string) {
+	requireGitHubAccess()
+}
+
+func runDeployCmd(cmd *cobra.Command, args []string) {
+	workflow := args[0]
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+	repo, err := git.ProjectName()
+	failIfError(err)
+
+	rawInputs, _ := cmd.Flags().GetStringArray("input")
+	inputs, err := parseInputs(rawInputs)
+	failIfError(err)
+	interval, _ := cmd.Flags().GetDuration("interval")
+	tail, _ := cmd.Flags().GetInt("tail")
+
+	client := github.NewClient(config.GitHub)
+	// GitHub's timestamps have second precision so allow for the truncation.
+	dispatchedAt := time.Now().Add(-time.Second)
+	fmt.Printf("Dispatching %s on %s...\n", workflow, branch)
+	failIfError(client.DispatchWorkflow(repo, workflow, branch, inputs))
+
+	run := waitForRun(client, repo, workflow, branch, dispatchedAt, interval)
+	fmt.Println(run.URL)
+
+	followRun(client, repo, run, interval, tail)
+	if run.State() != github.CheckSuccess {
+		failIfError(fmt.Errorf("workflow run finished with the %s conclusion", run.Conclusion))
+	}
+}
+
+// parseInputs parses the key=value workflow inputs.
+func parseInputs(raw []string) (map[string]string, error) {
+	inputs := make(map[string]string)
+	for _, input := range raw {
+		kv := strings.SplitN(input, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid input %q. expected key=value", input)
+		}
+		inputs[kv[0]] = kv[1]
+	}
+	return inputs, nil
+}
+
+// waitForRun polls until the dispatched workflow run is created.
+func waitForRun(client *github.Client, repo, workflow, branch string, since time.Time, interval time.Duration) *github.WorkflowRun {
+	fmt.Println("Waiting for the workflow run to start...")
+	deadline := time.Now().Add(runLookupTimeout)
+	for time.Now().Before(deadline) {
+		run, err := client.FindDispatchedRun(repo, workflow, branch, since)
+		failIfError(err)
+		if run != nil {
+			return run
+		}
+		time.Sleep(interval)
+	}
+	failIfError(fmt.Errorf("no %s run found on %s after %s", workflow, branch, runLookupTimeout))
+	return nil
+}
+
+// followRun polls the workflow run, showing job status changes and the log tail of completed jobs,
+// until the run completes.
+func followRun(client *github.Client, repo string, run *github.WorkflowRun, interval time.Duration, tail int) {
+	states := map[string]func(a ...interface{}) string{
+		github.CheckSuccess: color.New(color.FgGreen).SprintFunc(),
+		github.CheckPending: color.New(color.FgYellow).SprintFunc(),
+		github.CheckFailure: color.New(color.FgRed).SprintFunc(),
+	}
+	faint := color.New(color.Faint).SprintFunc()
+
+	seen := make(map[int64]string)
+	for {
+		// Get the run before its jobs so no job changes are missed once it completes.
+		updated, err := client.WorkflowRun(repo, run.ID)
+		failIfError(err)
+		*run = *updated
+
+		jobs, err := client.Jobs(repo, run.ID)
+		failIfError(err)
+
+		for _, j := range jobs {
+			status := j.Status
+			if j.Conclusion != "" {
+				status = j.Conclusion
+			}
+			if seen[j.ID] == status {
+				continue
+			}
+			seen[j.ID] = status
+			fmt.Printf("  %s %s\n", states[j.State()](fmt.Sprintf("%-12s", status)), j.Name)
+
+			if j.Status == "completed" && tail > 0 {
+				lines, err := client.JobLogTail(repo, j.ID, tail)
+				warnIfError(err)
+				for _, line := range lines {
+					fmt.Println(faint("      " + line))
+				}
+			}
+		}
+
+		if run.Status == "completed" {
+			return
+		}
+		time.Sleep(interval)
+	}
+}
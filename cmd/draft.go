@@ -1,13 +1,9 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/spf13/cobra"
 
 	"github.com/greganswer/workflow/git"
-	"github.com/greganswer/workflow/github"
 	"github.com/greganswer/workflow/issues"
 	"github.com/greganswer/workflow/jira"
 )
@@ -22,20 +18,13 @@ var draftCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(draftCmd)
+	draftCmd.Flags().StringP("template", "t", "", "name or path of the pull request template to use")
+	draftCmd.Flags().StringP("milestone", "m", "", "title of the milestone to add the pull request to")
 }
 
 func preRunDraftCmd(cmd *cobra.Command, _ []string) {
-	force, _ := cmd.Flags().GetBool("force")
-	if !force && !git.RepoIsClean() {
-		failIfError(git.RepoIsDirtyErr)
-	}
-	if !github.CLIExists() {
-		fmt.Println("The 'gh' CLI app is required to execute this command.")
-		if confirm("Open URL with instructions") {
-			openURL(github.CLIInstallationInstructions)
-		}
-		os.Exit(1)
-	}
+	failIfDirty(cmd)
+	requireGitHubAccess()
 }
 
 // TODO: Handle uncommitted changes
@@ -49,17 +38,11 @@ func runDraftCmd(cmd *cobra.Command, _ []string) {
 	issue, err := jira.GetIssue(ID, config.Jira)
 	failIfError(err)
 
-	baseBranch, _ := cmd.Flags().GetString("base")
-	reviewers := os.Getenv("WORKFLOW_PR_REVIEWERS")
-	pr, err := github.NewPr(issue, baseBranch, reviewers, true)
-	warnIfError(err)
-
-	displayIssueAndPRInfo(issue, pr)
+	remote := branchStatus(cmd, branch)
+	pr := newPullRequest(cmd, issue, branch, true)
 
-	if !confirm("Create this pull request") {
-		os.Exit(1)
-	}
+	displayIssueAndPRInfo(issue, pr, &remote)
 
-	failIfError(pr.Create())
-	failIfError(github.OpenPR(branch))
+	result := createOrUpdatePullRequest(pr, &remote)
+	openURL(result.URL)
 }
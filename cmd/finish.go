@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// finishCmd represents the finish command.
+var finishCmd = &cobra.Command{
+	Use:    "finish",
+	Short:  "Close out the current branch once its pull request is merged",
+	PreRun: preRunFinishCmd,
+	Run:    runFinishCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(finishCmd)
+}
+
+func preRunFinishCmd(cmd *cobra.Command, _ []string) {
+	failIfDirty(cmd)
+	requireGitHubAccess()
+}
+
+func runFinishCmd(cmd *cobra.Command, _ []string) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	baseBranch, _ := cmd.Flags().GetString("base")
+	if branch == baseBranch {
+		failIfError(fmt.Errorf("already on the %s base branch", baseBranch))
+	}
+
+	ID := issues.ParseIDFromBranch(branch)
+	issue, err := jira.GetIssue(ID, config.Jira)
+	failIfError(err)
+
+	_, pr := findCurrentPullRequest()
+	if !pr.IsMerged() {
+		failIfError(fmt.Errorf("the pull request for %s has not been merged", branch))
+	}
+
+	displayIssueAndBranchInfo(issue, baseBranch)
+	if !confirm("Finish this branch") {
+		os.Exit(1)
+	}
+
+	finishBranch(issue, branch, baseBranch)
+}
+
+// finishBranch switches to the updated base branch, deletes the branch and completes the issue.
+func finishBranch(issue issues.Issue, branch, baseBranch string) {
+	failIfError(git.Checkout(baseBranch))
+	failIfError(git.Pull())
+	failIfError(git.DeleteBranch(branch))
+	// The remote branch may already have been deleted when the PR was merged.
+	warnIfError(git.DeleteRemoteBranch(branch))
+	// The issue is completed last so it stays open if the branch could not be cleaned up.
+	failIfError(jira.TransitionToDone(issue, config.Jira))
+}
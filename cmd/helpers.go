@@ -2,14 +2,19 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"runtime"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/manifoldco/promptui"
 	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
 
 	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
 	"github.com/greganswer/workflow/issues"
 )
 
@@ -41,6 +46,25 @@ func promptString(label string) (string, error) {
 	return prompt.Run()
 }
 
+// promptEdit lets the user edit the value. The value may be left blank.
+func promptEdit(label, value string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		Default:   value,
+		AllowEdit: true,
+	}
+	return prompt.Run()
+}
+
+func promptSelect(label string, items []string) (string, error) {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+	}
+	_, result, err := prompt.Run()
+	return result, err
+}
+
 // failIfError exits the program with a standardized error message if an error occurred.
 func failIfError(err error) {
 	if err != nil {
@@ -62,6 +86,132 @@ func openURL(URL string) {
 	failIfError(browser.OpenURL(URL))
 }
 
+// requireGitHubAccess exits the program if there is neither a GitHub token nor the "gh" CLI app.
+func requireGitHubAccess() {
+	if config.GitHub.Token != "" || github.CLIExists() {
+		return
+	}
+	fmt.Printf("A GitHub token ('%s' config or %s) or the 'gh' CLI app is required to execute this command.\n",
+		github.TokenConfigKey, github.TokenEnvVar(config.GitHub.Host))
+	if confirm("Open URL with instructions") {
+		openURL(github.CLIInstallationInstructions)
+	}
+	os.Exit(1)
+}
+
+// failIfDirty exits the program, listing the files that block the command,
+// if the repo has uncommitted changes or untracked files and the --force flag is not given.
+func failIfDirty(cmd *cobra.Command) {
+	force, _ := cmd.Flags().GetBool("force")
+	if force {
+		return
+	}
+	status, err := git.GetStatus()
+	failIfError(err)
+	if status.IsClean() {
+		return
+	}
+	displayDirtyFiles(status)
+	failIfError(fmt.Errorf("%w. commit or stash them, or use --force", git.RepoIsDirtyErr))
+}
+
+// displayDirtyFiles in a nicely formatted way.
+func displayDirtyFiles(s git.Status) {
+	yellow := color.New(color.FgYellow).SprintFunc()
+	groups := []struct {
+		label string
+		files []string
+	}{
+		{"Conflicted:", s.Conflicted},
+		{"Staged:", s.Staged},
+		{"Unstaged:", s.Unstaged},
+		{"Untracked:", s.Untracked},
+	}
+
+	fmt.Println()
+	title("  Uncommitted changes:")
+	for _, g := range groups {
+		if len(g.files) == 0 {
+			continue
+		}
+		fmt.Println(yellow("    " + g.label))
+		for _, f := range g.files {
+			fmt.Println("      " + f)
+		}
+	}
+	fmt.Println()
+}
+
+// baseBranchFor returns the recorded parent of a stacked branch, unless the --base flag is given,
+// or the --base flag value.
+func baseBranchFor(cmd *cobra.Command, branch string) string {
+	baseBranch, _ := cmd.Flags().GetString("base")
+	if cmd.Flags().Changed("base") {
+		return baseBranch
+	}
+	if parent := git.BranchParent(branch); parent != "" {
+		return parent
+	}
+	return baseBranch
+}
+
+// findCurrentPullRequest returns the repo ("owner/name") and the PR for the current branch.
+func findCurrentPullRequest() (string, *github.PullRequestResult) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+	repo, err := git.ProjectName()
+	failIfError(err)
+
+	pr, err := github.NewClient(config.GitHub).FindPullRequest(repo, branch)
+	failIfError(err)
+	return repo, pr
+}
+
+// editText opens the text in the git editor and returns the edited text.
+func editText(text string) (string, error) {
+	editor, err := git.Editor()
+	if err != nil {
+		return "", err
+	}
+
+	f, err := ioutil.TempFile("", "workflow-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.WriteString(text); err != nil {
+		return "", err
+	}
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	// The editor may include arguments, so it is run by the shell like git does.
+	c := exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name())
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = c.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+
+	b, err := ioutil.ReadFile(f.Name())
+	return string(b), err
+}
+
+// notify shows a desktop notification, if the platform supports it.
+func notify(title, message string) {
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		c = exec.Command("osascript", "-e", script)
+	case "linux":
+		c = exec.Command("notify-send", title, message)
+	default:
+		return
+	}
+	warnIfError(c.Run())
+}
+
 func displayIssueInfo(i issues.Issue) {
 	cyan := color.New(color.FgHiCyan).SprintFunc()
 	projectName, err := git.ProjectName()
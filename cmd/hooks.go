@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/commit"
+	"github.com/greganswer/workflow/file"
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/issues"
+)
+
+// Git hooks installed by the hooks install command.
+const (
+	prepareCommitMsgHook = "prepare-commit-msg"
+	commitMsgHook        = "commit-msg"
+)
+
+// hookMarker identifies the hooks written by workflow, so they are replaced instead of chained.
+const hookMarker = "# Installed by workflow."
+
+// chainedHookSuffix is added to the name of an existing hook that is run before the workflow hook.
+const chainedHookSuffix = ".workflow-chained"
+
+// hookScript runs the chained hook, if any, then the workflow hook subcommand.
+const hookScript = `#!/bin/sh
+` + hookMarker + ` Reinstall with 'workflow hooks install'.
+chained="$0` + chainedHookSuffix + `"
+if [ -x "$chained" ]; then
+	"$chained" "$@" || exit $?
+fi
+exec %s hooks %s "$@"
+`
+
+// hooksCmd represents the hooks command.
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage the git hooks that add and check issue IDs in commit messages",
+}
+
+// hooksInstallCmd represents the hooks install command.
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the prepare-commit-msg and commit-msg git hooks",
+	Long: `Install git hooks in the hooks directory of the repo, respecting core.hooksPath.
+The prepare-commit-msg hook prefixes the issue ID of the branch to commit messages.
+The commit-msg hook rejects commit messages without an issue ID, except merge, revert, fixup and squash commits.
+Existing hooks are kept and run before the workflow hooks.`,
+	Run: runHooksInstallCmd,
+}
+
+// prepareCommitMsgCmd is run by the prepare-commit-msg git hook.
+var prepareCommitMsgCmd = &cobra.Command{
+	Use:    prepareCommitMsgHook + " <file> [source] [sha]",
+	Short:  "Prefix the issue ID of the branch to the commit message",
+	Hidden: true,
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	Args:        validateHookCmdArgs,
+	Run:         runPrepareCommitMsgCmd,
+}
+
+// commitMsgCmd is run by the commit-msg git hook.
+var commitMsgCmd = &cobra.Command{
+	Use:    commitMsgHook + " <file>",
+	Short:  "Reject commit messages without an issue ID",
+	Hidden: true,
+	Annotations: map[string]string{skipConfigAnnotation: "true"},
+	Args:        validateHookCmdArgs,
+	Run:         runCommitMsgCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(prepareCommitMsgCmd)
+	hooksCmd.AddCommand(commitMsgCmd)
+}
+
+func validateHookCmdArgs(_ *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("requires the commit message file argument")
+	}
+	return nil
+}
+
+func runHooksInstallCmd(_ *cobra.Command, _ []string) {
+	dir, err := git.HooksDir()
+	failIfError(err)
+	executable, err := os.Executable()
+	failIfError(err)
+
+	failIfError(os.MkdirAll(dir, 0755))
+	for _, name := range []string{prepareCommitMsgHook, commitMsgHook} {
+		chained, err := installHook(dir, name, executable)
+		failIfError(err)
+		if chained {
+			fmt.Printf("Installed %s. The existing hook was moved to %s%s and still runs first.\n", name, name, chainedHookSuffix)
+		} else {
+			fmt.Printf("Installed %s.\n", name)
+		}
+	}
+	fmt.Println("Hooks directory:", dir)
+}
+
+// installHook writes the workflow hook to the hooks directory and returns true if an existing hook was chained.
+// Hooks written by workflow are replaced.
+func installHook(dir, name, executable string) (bool, error) {
+	hookPath := filepath.Join(dir, name)
+	chained := false
+
+	current, err := ioutil.ReadFile(hookPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if err == nil && !strings.Contains(string(current), hookMarker) {
+		chainedPath := hookPath + chainedHookSuffix
+		if exists, _ := file.Exists(chainedPath); exists {
+			return false, fmt.Errorf("cannot chain %s because %s already exists. merge them by hand and try again", hookPath, chainedPath)
+		}
+		if err := os.Rename(hookPath, chainedPath); err != nil {
+			return false, err
+		}
+		chained = true
+	}
+
+	script := fmt.Sprintf(hookScript, shellQuote(executable), name)
+	return chained, ioutil.WriteFile(hookPath, []byte(script), 0755)
+}
+
+// shellQuote quotes the string for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runPrepareCommitMsgCmd prefixes the issue ID of the branch to the message in the file.
+// Messages of amended and reused commits are left as they are.
+func runPrepareCommitMsgCmd(_ *cobra.Command, args []string) {
+	if len(args) > 1 && args[1] == "commit" {
+		return
+	}
+
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+	ID := strings.ToUpper(issues.ParseIDFromBranch(branch))
+	if ID == "" {
+		return
+	}
+
+	message, err := ioutil.ReadFile(args[0])
+	failIfError(err)
+	prefixed := commit.PrefixIssueID(string(message), ID)
+	if prefixed != string(message) {
+		failIfError(ioutil.WriteFile(args[0], []byte(prefixed), 0644))
+	}
+}
+
+// runCommitMsgCmd rejects the message in the file if it has no issue ID.
+func runCommitMsgCmd(_ *cobra.Command, args []string) {
+	message, err := ioutil.ReadFile(args[0])
+	failIfError(err)
+	if err := commit.RequireIssueID(string(message)); err != nil {
+		failIfError(fmt.Errorf("%v. use 'git commit --no-verify' to skip this check", err))
+	}
+}
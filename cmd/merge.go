@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// mergeCmd represents the merge command.
+var mergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge the pull request for the current branch and complete the Jira issue",
+	Long: `Wait for the checks and approvals of the pull request for the current branch, merge it,
+move the Jira issue to done and delete the branch.`,
+	PreRun: preRunMergeCmd,
+	Run:    runMergeCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.Flags().String("method", "", "merge method: merge, squash or rebase (default squash)")
+	mergeCmd.Flags().Duration("interval", 10*time.Second, "time between polls while waiting for checks and approvals")
+	mergeCmd.Flags().Duration("grace", 2*time.Minute, "how long to wait for the first checks when no checks are required")
+}
+
+func preRunMergeCmd(cmd *cobra.Command, _ []string) {
+	failIfDirty(cmd)
+	requireGitHubAccess()
+}
+
+func runMergeCmd(cmd *cobra.Command, _ []string) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	ID := issues.ParseIDFromBranch(branch)
+	issue, err := jira.GetIssue(ID, config.Jira)
+	failIfError(err)
+
+	force, _ := cmd.Flags().GetBool("force")
+	if !force && !jira.IsInCodeReview(issue) {
+		failIfError(fmt.Errorf("Jira issue %s has the '%s' status. use --force to merge anyway", issue.ID, issue.Status))
+	}
+
+	method, _ := cmd.Flags().GetString("method")
+	if method == "" {
+		method = config.getString(github.MergeMethodConfigKey)
+	}
+	if method == "" {
+		method = github.MergeMethodSquash
+	}
+	failIfError(github.ValidateMergeMethod(method))
+
+	repo, err := git.ProjectName()
+	failIfError(err)
+	client := github.NewClient(config.GitHub)
+	pr, err := client.FindOpenPullRequest(repo, branch)
+	failIfError(err)
+	if pr == nil {
+		failIfError(fmt.Errorf("no open pull request found for %s", branch))
+	}
+	if pr.Draft {
+		failIfError(errors.New("draft pull requests cannot be merged. run the ready command first"))
+	}
+
+	commitTitle := fmt.Sprintf("%s (#%d)", issue, pr.Number)
+	baseBranch := pr.Base.Ref
+	displayIssueAndBranchInfo(issue, baseBranch)
+	fmt.Printf("Pull request #%d will be merged with the %s method as %q\n\n", pr.Number, method, commitTitle)
+	if !confirm("Merge this pull request") {
+		os.Exit(1)
+	}
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	grace, _ := cmd.Flags().GetDuration("grace")
+	waitForChecksAndApprovals(client, repo, pr, interval, grace)
+
+	fmt.Printf("Merging pull request #%d...\n", pr.Number)
+	failIfError(client.MergePullRequest(repo, pr, method, commitTitle))
+	finishBranch(issue, branch, baseBranch)
+}
+
+// waitForChecksAndApprovals polls the PR until its required checks pass and it is approved.
+// Optional checks are ignored. If no checks are required, it waits up to the grace period
+// for any check to be reported, since CI may not have started yet.
+// Exits the program if a required check fails or changes are requested.
+func waitForChecksAndApprovals(client *github.Client, repo string, pr *github.PullRequestResult, interval, grace time.Duration) {
+	graceEnd := time.Now().Add(grace)
+	for {
+		checks, err := client.PullRequestChecks(repo, pr)
+		failIfError(err)
+		decision, err := client.ReviewDecision(repo, pr.Number)
+		failIfError(err)
+
+		required := github.RequiredChecks(checks)
+		state := github.ChecksState(required)
+		if state == github.CheckNone {
+			state = github.CheckSuccess
+			if len(checks) == 0 && time.Now().Before(graceEnd) {
+				state = github.CheckPending
+			}
+		}
+
+		switch {
+		case state == github.CheckFailure:
+			displayChecks(pr, required)
+			failIfError(fmt.Errorf("required checks failed for pull request #%d", pr.Number))
+		case decision == github.ReviewChangesRequested:
+			failIfError(fmt.Errorf("changes were requested on pull request #%d", pr.Number))
+		case state == github.CheckPending:
+			fmt.Println("Waiting for required checks to complete...")
+		case decision == github.ReviewRequired:
+			fmt.Println("Waiting for approval...")
+		default:
+			return
+		}
+		time.Sleep(interval)
+	}
+}
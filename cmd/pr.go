@@ -3,10 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/greganswer/workflow/codeowners"
+	"github.com/greganswer/workflow/file"
 	"github.com/greganswer/workflow/git"
 	"github.com/greganswer/workflow/github"
 	"github.com/greganswer/workflow/issues"
@@ -23,20 +26,13 @@ var prCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(prCmd)
+	prCmd.Flags().StringP("template", "t", "", "name or path of the pull request template to use")
+	prCmd.Flags().StringP("milestone", "m", "", "title of the milestone to add the pull request to")
 }
 
 func preRunPrCmd(cmd *cobra.Command, _ []string) {
-	force, _ := cmd.Flags().GetBool("force")
-	if !force && !git.RepoIsClean() {
-		failIfError(git.RepoIsDirtyErr)
-	}
-	if !github.CLIExists() {
-		fmt.Println("The 'gh' CLI app is required to execute this command.")
-		if confirm("Open URL with instructions") {
-			openURL(github.CLIInstallationInstructions)
-		}
-		os.Exit(1)
-	}
+	failIfDirty(cmd)
+	requireGitHubAccess()
 }
 
 // TODO: Add a draft flag and replace the contents of runDraftCmd
@@ -51,24 +47,230 @@ func runPrCmd(cmd *cobra.Command, _ []string) {
 	issue, err := jira.GetIssue(ID, config.Jira)
 	failIfError(err)
 
-	baseBranch, _ := cmd.Flags().GetString("base")
-	reviewers := os.Getenv("WORKFLOW_PR_REVIEWERS")
-	pr, err := github.NewPr(issue, baseBranch, reviewers, false)
-	warnIfError(err)
+	remote := branchStatus(cmd, branch)
+	pr := newPullRequest(cmd, issue, branch, false)
 
-	displayIssueAndPRInfo(issue, pr)
+	displayIssueAndPRInfo(issue, pr, &remote)
+
+	result := createOrUpdatePullRequest(pr, &remote)
+	openURL(result.URL)
+	// An existing PR may have been kept as a draft.
+	if !result.Draft {
+		failIfError(jira.TransitionToCodeReview(issue, config.Jira))
+	}
+}
+
+// createOrUpdatePullRequest creates the PR after confirmation or,
+// if the branch already has an open PR, offers to update it instead.
+// The head branch is pushed first when its remote branch is given.
+func createOrUpdatePullRequest(pr github.PullRequest, remote *git.RemoteBranch) *github.PullRequestResult {
+	repo, err := git.ProjectName()
+	failIfError(err)
+
+	client := github.NewClient(config.GitHub)
+	existing, err := client.FindOpenPullRequest(repo, pr.Head)
+	failIfError(err)
+	if existing != nil {
+		pushBranch(remote)
+		updatePullRequest(client, repo, existing, pr)
+		return existing
+	}
 
 	if !confirm("Create this pull request") {
 		os.Exit(1)
 	}
+	pushBranch(remote)
+	return createPullRequest(pr)
+}
+
+// branchStatus compares the current branch with its copy on the push remote.
+// Fails if the remote branch has commits the local branch does not, unless --force is given.
+func branchStatus(cmd *cobra.Command, branch string) git.RemoteBranch {
+	remote, err := git.CompareRemoteBranch(branch)
+	warnIfError(err)
+
+	force, _ := cmd.Flags().GetBool("force")
+	if remote.Behind > 0 && !force {
+		failIfError(fmt.Errorf("%s is %d commit(s) behind %s. pull the changes first, or use --force",
+			branch, remote.Behind, remote.Ref()))
+	}
+	return remote
+}
+
+// pushBranch pushes the branch if the remote branch is missing or behind.
+// The remote branch becomes the upstream if it is not already. Does nothing if remote is nil.
+func pushBranch(remote *git.RemoteBranch) {
+	if remote == nil || !remote.NeedsPush() {
+		return
+	}
+	if remote.Tracked {
+		failIfError(git.Push(remote.Remote, remote.Branch))
+	} else {
+		failIfError(git.PushSetUpstream(remote.Remote, remote.Branch))
+	}
+}
+
+// updatePullRequest offers to update the title, generated body section, reviewers
+// and draft state of the existing PR to match the new one.
+func updatePullRequest(client *github.Client, repo string, existing *github.PullRequestResult, pr github.PullRequest) {
+	fmt.Printf("Pull request #%d already exists: %s\n", existing.Number, existing.URL)
+
+	var newTitle, newBody string
+	if existing.Title != pr.Title && confirm(fmt.Sprintf("Update the title from %q", existing.Title)) {
+		newTitle = pr.Title
+	}
+
+	body, err := github.ReplaceGeneratedSection(existing.Body, pr.Body)
+	if err == github.ErrNoGeneratedSection {
+		newBody = chooseBodyUpdate(existing.Body, pr.Body)
+	} else if body != existing.Body && confirm("Update the generated section of the body") {
+		newBody = body
+	}
+	failIfError(client.UpdatePullRequest(repo, existing.Number, newTitle, newBody))
+
+	reviewers := append(append([]string{}, pr.Reviewers...), pr.TeamReviewers...)
+	if len(reviewers) > 0 && confirm("Request reviews from "+strings.Join(reviewers, ", ")) {
+		warnIfError(client.RequestReviewers(repo, existing.Number, pr.Reviewers, pr.TeamReviewers))
+	}
+
+	if existing.Draft != pr.Draft {
+		label := "Mark the pull request as ready for review"
+		if pr.Draft {
+			label = "Convert the pull request to a draft"
+		}
+		if confirm(label) {
+			failIfError(client.SetDraft(existing, pr.Draft))
+		}
+	}
+}
+
+// Ways to update a PR body without generated section markers.
+const (
+	bodyInsert  = "Insert the generated section at the top"
+	bodyReplace = "Replace the whole body"
+	bodyKeep    = "Keep the current body"
+)
+
+// chooseBodyUpdate asks how to update a PR body that has no generated section markers,
+// such as bodies of PRs created before the markers or from templates without them.
+// Returns an empty string to keep the current body.
+func chooseBodyUpdate(current, generated string) string {
+	fmt.Println("The body of the pull request has no generated section.")
+	choice, err := promptSelect("Update the body", []string{bodyInsert, bodyReplace, bodyKeep})
+	failIfError(err)
+	switch choice {
+	case bodyInsert:
+		return github.InsertGeneratedSection(current, generated)
+	case bodyReplace:
+		return generated
+	}
+	return ""
+}
+
+// newPullRequest renders the PR for the branch from the configured templates.
+func newPullRequest(cmd *cobra.Command, issue issues.Issue, branch string, draft bool) github.PullRequest {
+	baseBranch := baseBranchFor(cmd, branch)
+
+	format, err := config.prFormat()
+	failIfError(err)
+
+	commits, err := git.CommitSubjects(baseBranch + "..HEAD")
+	warnIfError(err)
+	files, err := git.ChangedFiles(baseBranch)
+	warnIfError(err)
+
+	reviewers := chooseReviewers(files)
+
+	data := github.PrData{
+		Issue:   issue,
+		Branch:  branch,
+		Base:    baseBranch,
+		Commits: commits,
+		Files:   files,
+	}
+	pr, err := github.NewPr(data, format, reviewers, choosePRTemplate(cmd, issue), draft)
+	failIfError(err)
+
+	if config.GitHub.Username != "" {
+		pr.Assignees = []string{config.GitHub.Username}
+	}
+	pr.Labels = github.LabelsForIssue(issue, config.githubLabels())
+	pr.Milestone, _ = cmd.Flags().GetString("milestone")
+	return pr
+}
+
+// createPullRequest on GitHub. Failing to request reviewers only warns since the PR already exists.
+func createPullRequest(pr github.PullRequest) *github.PullRequestResult {
+	repo, err := git.ProjectName()
+	failIfError(err)
+
+	result, err := pr.Create(github.NewClient(config.GitHub), repo)
+	if err == github.ErrPullRequestExists {
+		failIfError(fmt.Errorf("%w. run the command again to update it", err))
+	}
+	if result == nil {
+		failIfError(err)
+	}
+	warnIfError(err)
+
+	fmt.Printf("Created pull request #%d: %s\n", result.Number, result.URL)
+	return result
+}
+
+// chooseReviewers lets the user edit the reviewers from the WORKFLOW_PR_REVIEWERS env var
+// and the CODEOWNERS of the changed files. The PR author is never suggested.
+func chooseReviewers(files []string) string {
+	rules, err := codeowners.Load(git.RootDir())
+	warnIfError(err)
 
-	failIfError(pr.Create())
-	failIfError(github.OpenPR(branch))
-	failIfError(jira.TransitionToCodeReview(issue, config.Jira))
+	envReviewers := strings.Split(os.Getenv("WORKFLOW_PR_REVIEWERS"), ",")
+	suggested := codeowners.MergeReviewers(config.GitHub.Username, envReviewers, rules.Reviewers(files, config.GitHub.Username))
+
+	reviewers, err := promptEdit("Reviewers (comma separated)", strings.Join(suggested, ","))
+	failIfError(err)
+	return reviewers
+}
+
+// noTemplate is the option to create a PR without a template.
+const noTemplate = "None"
+
+// choosePRTemplate returns the path of the PR template from the --template flag,
+// the only template, the template matching the issue type or the user's choice, in that order.
+func choosePRTemplate(cmd *cobra.Command, issue issues.Issue) string {
+	templates := github.FindTemplates(git.RootDir())
+
+	name, _ := cmd.Flags().GetString("template")
+	if name != "" {
+		if t := github.FindTemplate(templates, name); t != "" {
+			return t
+		}
+		if exists, _ := file.Exists(name); exists {
+			return name
+		}
+		failIfError(fmt.Errorf("pull request template not found: %s", name))
+	}
+
+	switch len(templates) {
+	case 0:
+		return ""
+	case 1:
+		return templates[0]
+	}
+
+	if t := github.TemplateForIssue(templates, issue); t != "" {
+		return t
+	}
+
+	choice, err := promptSelect("Pull request template", append(templates, noTemplate))
+	failIfError(err)
+	if choice == noTemplate {
+		return ""
+	}
+	return choice
 }
 
 // displayIssueAndPRInfo in a nicely formatted way.
-func displayIssueAndPRInfo(i issues.Issue, pr github.PullRequest) {
+func displayIssueAndPRInfo(i issues.Issue, pr github.PullRequest, remote *git.RemoteBranch) {
 	cyan := color.New(color.FgHiCyan).SprintFunc()
 	fmt.Println()
 	displayIssueInfo(i)
@@ -76,9 +278,22 @@ func displayIssueAndPRInfo(i issues.Issue, pr github.PullRequest) {
 	title("  Pull request:")
 	fmt.Println(cyan("    Title:"), pr.Title)
 	fmt.Println(cyan("    Base:"), pr.Base)
-	fmt.Println(cyan("    Reviewers:"), pr.Reviewers)
+	fmt.Println(cyan("    Reviewers:"), strings.Join(pr.Reviewers, ", "))
+	fmt.Println(cyan("    Team reviewers:"), strings.Join(pr.TeamReviewers, ", "))
+	fmt.Println(cyan("    Assignees:"), strings.Join(pr.Assignees, ", "))
+	fmt.Println(cyan("    Labels:"), strings.Join(pr.Labels, ", "))
+	fmt.Println(cyan("    Milestone:"), pr.Milestone)
 	fmt.Println(cyan("    Template:"), pr.Template)
 	fmt.Println(cyan("    Draft:"), pr.Draft)
+	if remote != nil && remote.Exists {
+		fmt.Println(cyan("    Remote:"), fmt.Sprintf("%s (%d ahead, %d behind)", remote.Ref(), remote.Ahead, remote.Behind))
+	} else if remote != nil {
+		fmt.Println(cyan("    Remote:"), "not pushed yet. the branch will be pushed to "+remote.Remote)
+	}
+	fmt.Println(cyan("    Body:"))
+	for _, line := range strings.Split(strings.TrimRight(pr.Body, "\n"), "\n") {
+		fmt.Println("      " + line)
+	}
 
 	fmt.Println()
 }
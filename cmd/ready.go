@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// readyCmd represents the ready command.
+var readyCmd = &cobra.Command{
+	Use:    "ready",
+	Short:  "Mark the draft pull request for the current branch as ready for review",
+	Long:   "Mark the draft pull request for the current branch as ready for review, request reviewers and move the Jira issue to code review.",
+	PreRun: preRunReadyCmd,
+	Run:    runReadyCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(readyCmd)
+}
+
+func preRunReadyCmd(_ *cobra.Command, _ []string) {
+	requireGitHubAccess()
+}
+
+func runReadyCmd(cmd *cobra.Command, _ []string) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	ID := issues.ParseIDFromBranch(branch)
+	issue, err := jira.GetIssue(ID, config.Jira)
+	failIfError(err)
+
+	repo, err := git.ProjectName()
+	failIfError(err)
+	client := github.NewClient(config.GitHub)
+	pr, err := client.FindOpenPullRequest(repo, branch)
+	failIfError(err)
+	if pr == nil {
+		failIfError(fmt.Errorf("no open pull request found for %s", branch))
+	}
+
+	files, err := git.ChangedFiles(baseBranchFor(cmd, branch))
+	warnIfError(err)
+	users, teams := github.SplitReviewers(chooseReviewers(files))
+
+	displayIssueAndReadyInfo(issue, pr, append(users, teams...))
+	if !confirm("Mark this pull request as ready for review") {
+		os.Exit(1)
+	}
+
+	if pr.Draft {
+		failIfError(client.SetDraft(pr, false))
+	} else {
+		fmt.Printf("Pull request #%d is already ready for review\n", pr.Number)
+	}
+	warnIfError(client.RequestReviewers(repo, pr.Number, users, teams))
+	failIfError(jira.TransitionToCodeReview(issue, config.Jira))
+	fmt.Println(pr.URL)
+}
+
+// displayIssueAndReadyInfo in a nicely formatted way.
+func displayIssueAndReadyInfo(i issues.Issue, pr *github.PullRequestResult, reviewers []string) {
+	cyan := color.New(color.FgHiCyan).SprintFunc()
+	fmt.Println()
+	displayIssueInfo(i)
+
+	title("  Pull request:")
+	fmt.Println(cyan("    Number:"), pr.Number)
+	fmt.Println(cyan("    Title:"), pr.Title)
+	fmt.Println(cyan("    URL:"), pr.URL)
+	fmt.Println(cyan("    Draft:"), pr.Draft)
+	fmt.Println(cyan("    Reviewers:"), strings.Join(reviewers, ", "))
+
+	fmt.Println()
+}
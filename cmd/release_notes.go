@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/changelog"
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/jira"
+)
+
+// releaseNotesCmd represents the release-notes command.
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes <version>",
+	Short: "Generate release notes from the issues in a Jira fix version",
+	Long: `Generate markdown release notes from the issues in a Jira fix version.
+Issues are grouped by type and linked to the pull requests merged into the base branch.`,
+	Args: validateReleaseNotesCmdArgs,
+	Run:  runReleaseNotesCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(releaseNotesCmd)
+	releaseNotesCmd.Flags().String("format", changelog.ReleaseFormat, "output format: release or changelog")
+	releaseNotesCmd.Flags().StringP("output", "o", "", "write the notes to this file instead of the terminal")
+}
+
+func validateReleaseNotesCmdArgs(_ *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("requires the version argument")
+	}
+	return nil
+}
+
+func runReleaseNotesCmd(cmd *cobra.Command, args []string) {
+	version := args[0]
+	list, err := jira.GetIssuesByFixVersion(version, config.Jira)
+	failIfError(err)
+
+	baseBranch, _ := cmd.Flags().GetString("base")
+	subjects, err := git.CommitSubjects(baseBranch)
+	failIfError(err)
+
+	format, _ := cmd.Flags().GetString("format")
+	notes, err := changelog.NewNotes(version, list, subjects).Markdown(format)
+	failIfError(err)
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		fmt.Println()
+		fmt.Println(notes)
+		return
+	}
+	failIfError(ioutil.WriteFile(output, []byte(notes+"\n"), 0644))
+	fmt.Printf("Release notes written to %s\n", output)
+}
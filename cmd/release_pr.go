@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/changelog"
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// releasePrCmd represents the release-pr command.
+var releasePrCmd = &cobra.Command{
+	Use:   "release-pr [title]",
+	Short: "Create a release pull request from the base branch to the main branch",
+	Long: `Create a release pull request from the base branch to the main branch.
+The body is a changelog of the Jira issues found in the commits being released.`,
+	PreRun: preRunReleasePrCmd,
+	Run:    runReleasePrCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(releasePrCmd)
+	releasePrCmd.Flags().String("main", "main", "branch the release is merged into")
+}
+
+func preRunReleasePrCmd(_ *cobra.Command, _ []string) {
+	requireGitHubAccess()
+}
+
+func runReleasePrCmd(cmd *cobra.Command, args []string) {
+	baseBranch, _ := cmd.Flags().GetString("base")
+	mainBranch, _ := cmd.Flags().GetString("main")
+
+	prTitle := "Release " + time.Now().Format("2006-01-02")
+	if len(args) > 0 {
+		prTitle = args[0]
+	}
+
+	subjects, err := git.CommitSubjects(mainBranch + ".." + baseBranch)
+	failIfError(err)
+	if len(subjects) == 0 {
+		failIfError(fmt.Errorf("%s has no commits that are not in %s", baseBranch, mainBranch))
+	}
+
+	list, errs := jira.GetIssues(issueIDsFromCommits(subjects), config.Jira)
+	for _, err := range errs {
+		warnIfError(err)
+	}
+
+	notes, err := changelog.NewNotes(prTitle, list, subjects).Markdown(changelog.ReleaseFormat)
+	failIfError(err)
+
+	pr := github.PullRequest{
+		Title:    prTitle,
+		Head:     baseBranch,
+		Base:     mainBranch,
+		Body:     github.GeneratedStartMarker + "\n" + notes + "\n" + github.GeneratedEndMarker + "\n",
+		Template: "None",
+	}
+
+	fmt.Println()
+	title("  Release pull request:")
+	fmt.Printf("    %s (%s → %s, %d commits, %d issues)\n\n", pr.Title, pr.Head, pr.Base, len(subjects), len(list))
+	fmt.Println(notes)
+	fmt.Println()
+
+	result := createOrUpdatePullRequest(pr, nil)
+	openURL(result.URL)
+}
+
+// issueIDsFromCommits returns the unique issue IDs in the commit subjects, oldest first.
+func issueIDsFromCommits(subjects []string) []string {
+	var IDs []string
+	seen := make(map[string]bool)
+	for i := len(subjects) - 1; i >= 0; i-- {
+		ID := issues.ParseIDFromCommit(subjects[i])
+		if ID == "" || seen[ID] {
+			continue
+		}
+		seen[ID] = true
+		IDs = append(IDs, ID)
+	}
+	return IDs
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+)
+
+// restackCmd represents the restack command.
+var restackCmd = &cobra.Command{
+	Use:   "restack",
+	Short: "Rebase stacked branches onto their updated parents",
+	Long: `Rebase each stacked branch, in order, onto its parent branch.
+Branches whose parent pull request has been merged are moved onto the base branch
+and their pull requests are retargeted to it.`,
+	PreRun: preRunRestackCmd,
+	Run:    runRestackCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(restackCmd)
+	restackCmd.Flags().BoolP("push", "p", false, "force push each rebased branch with lease")
+}
+
+func preRunRestackCmd(cmd *cobra.Command, _ []string) {
+	failIfDirty(cmd)
+	requireGitHubAccess()
+}
+
+func runRestackCmd(cmd *cobra.Command, _ []string) {
+	current, err := git.CurrentBranch()
+	failIfError(err)
+
+	stacks, err := git.StackedBranches()
+	failIfError(err)
+	if len(stacks) == 0 {
+		fmt.Println("No stacked branches found.")
+		return
+	}
+
+	order := stackOrder(stacks)
+	displayStacks(order, stacks)
+	if !confirm("Restack these branches") {
+		os.Exit(1)
+	}
+
+	baseBranch, _ := cmd.Flags().GetString("base")
+	push, _ := cmd.Flags().GetBool("push")
+	repo, err := git.ProjectName()
+	failIfError(err)
+	client := github.NewClient(config.GitHub)
+
+	baseUpdated := false
+	for _, branch := range order {
+		parent := stacks[branch]
+		// Only the commits after the recorded parent commit are moved, so commits of a parent
+		// that was rebased, squash merged or deleted are not replayed.
+		upstream := git.BranchParentTip(branch)
+		if upstream == "" {
+			upstream = parent
+		}
+
+		if parent != baseBranch && isMerged(client, repo, parent) {
+			fmt.Printf("%s has been merged. Moving %s onto %s...\n", parent, branch, baseBranch)
+			if !baseUpdated {
+				failIfError(git.Checkout(baseBranch))
+				failIfError(git.Pull())
+				baseUpdated = true
+			}
+			failIfError(restackErr(git.RebaseOnto(baseBranch, upstream, branch)))
+			failIfError(git.UnsetBranchParent(branch))
+			retargetPullRequest(client, repo, branch, baseBranch)
+		} else {
+			fmt.Printf("Rebasing %s onto %s...\n", branch, parent)
+			failIfError(restackErr(git.RebaseOnto(parent, upstream, branch)))
+			tip, err := git.RevParse(parent)
+			failIfError(err)
+			failIfError(git.SetBranchParent(branch, parent, tip))
+		}
+
+		if push {
+			failIfError(git.ForcePushWithLease(branch))
+		}
+	}
+
+	failIfError(git.Checkout(current))
+}
+
+// stackOrder returns the stacked branches ordered so every parent comes before its children.
+func stackOrder(stacks map[string]string) []string {
+	children := make(map[string][]string)
+	var roots []string
+	for branch, parent := range stacks {
+		children[parent] = append(children[parent], branch)
+		if _, stacked := stacks[parent]; !stacked {
+			roots = append(roots, branch)
+		}
+	}
+	sort.Strings(roots)
+
+	var order []string
+	queue := roots
+	for len(queue) > 0 {
+		branch := queue[0]
+		queue = queue[1:]
+		order = append(order, branch)
+		sort.Strings(children[branch])
+		queue = append(queue, children[branch]...)
+	}
+	return order
+}
+
+// isMerged returns true if the branch's PR has been merged.
+func isMerged(client *github.Client, repo, branch string) bool {
+	pr, err := client.FindPullRequest(repo, branch)
+	if err == github.ErrPullRequestNotFound {
+		return false
+	}
+	failIfError(err)
+	return pr.IsMerged()
+}
+
+// retargetPullRequest changes the base of the branch's open PR, if it has one.
+func retargetPullRequest(client *github.Client, repo, branch, base string) {
+	pr, err := client.FindOpenPullRequest(repo, branch)
+	failIfError(err)
+	if pr == nil || pr.Base.Ref == base {
+		return
+	}
+	fmt.Printf("Retargeting pull request #%d to %s...\n", pr.Number, base)
+	failIfError(client.SetBase(repo, pr.Number, base))
+}
+
+// restackErr explains how to recover from a failed rebase.
+func restackErr(err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%v. resolve the conflicts, run 'git rebase --continue' and run restack again", err)
+}
+
+// displayStacks in a nicely formatted way.
+func displayStacks(order []string, stacks map[string]string) {
+	cyan := color.New(color.FgHiCyan).SprintFunc()
+	fmt.Println()
+	title("  Stacked branches:")
+	for _, branch := range order {
+		fmt.Println("    "+branch, cyan("on"), stacks[branch])
+	}
+	fmt.Println()
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
+)
+
+// contextLines is the number of lines shown before and after a review comment's line.
+const contextLines = 2
+
+// reviewsCmd represents the reviews command.
+var reviewsCmd = &cobra.Command{
+	Use:    "reviews",
+	Short:  "Show the reviews and unresolved review comments on the current branch's pull request",
+	PreRun: preRunReviewsCmd,
+	Run:    runReviewsCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(reviewsCmd)
+}
+
+func preRunReviewsCmd(_ *cobra.Command, _ []string) {
+	requireGitHubAccess()
+}
+
+func runReviewsCmd(_ *cobra.Command, _ []string) {
+	repo, pr := findCurrentPullRequest()
+	client := github.NewClient(config.GitHub)
+
+	reviews, err := client.Reviews(repo, pr.Number)
+	failIfError(err)
+	threads, err := client.UnresolvedReviewThreads(repo, pr.Number)
+	failIfError(err)
+
+	fmt.Println()
+	displayReviews(pr, github.LatestReviews(reviews))
+	displayReviewThreads(threads)
+}
+
+// displayReviews in a nicely formatted way.
+func displayReviews(pr *github.PullRequestResult, reviews []github.Review) {
+	states := map[string]func(a ...interface{}) string{
+		github.ReviewStateApproved:         color.New(color.FgGreen).SprintFunc(),
+		github.ReviewStateChangesRequested: color.New(color.FgRed).SprintFunc(),
+	}
+
+	title(fmt.Sprintf("  Reviews for #%d:", pr.Number))
+	if len(reviews) == 0 {
+		fmt.Println("    No reviews yet.")
+	}
+	for _, r := range reviews {
+		state := strings.ToLower(strings.Replace(r.State, "_", " ", -1))
+		if c, ok := states[r.State]; ok {
+			state = c(state)
+		}
+		fmt.Printf("    %s: %s\n", r.User.Login, state)
+	}
+	fmt.Println()
+}
+
+// displayReviewThreads grouped by file, with the surrounding lines from the local checkout.
+func displayReviewThreads(threads []github.ReviewThread) {
+	cyan := color.New(color.FgHiCyan).SprintFunc()
+	faint := color.New(color.Faint).SprintFunc()
+
+	title(fmt.Sprintf("  Unresolved comments (%d):", len(threads)))
+
+	byFile := make(map[string][]github.ReviewThread)
+	var files []string
+	for _, t := range threads {
+		if _, ok := byFile[t.Path]; !ok {
+			files = append(files, t.Path)
+		}
+		byFile[t.Path] = append(byFile[t.Path], t)
+	}
+	sort.Strings(files)
+
+	root := git.RootDir()
+	for _, f := range files {
+		fmt.Println()
+		fmt.Println(cyan("    " + f))
+
+		lines := readLines(path.Join(root, f))
+		fileThreads := byFile[f]
+		sort.Slice(fileThreads, func(i, j int) bool { return fileThreads[i].Line < fileThreads[j].Line })
+		for _, t := range fileThreads {
+			label := fmt.Sprintf("Line %d", t.Line)
+			if t.Outdated {
+				label += " (outdated)"
+			}
+			fmt.Println("      " + label + ":")
+			for n := t.Line - contextLines; n <= t.Line+contextLines; n++ {
+				if n < 1 || n > len(lines) {
+					continue
+				}
+				marker := " "
+				if n == t.Line {
+					marker = ">"
+				}
+				fmt.Println(faint(fmt.Sprintf("      %s %4d | %s", marker, n, lines[n-1])))
+			}
+			for _, c := range t.Comments {
+				fmt.Printf("        %s: %s\n", c.Author, strings.Replace(c.Body, "\n", "\n          ", -1))
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// readLines of the file. Returns no lines if the file cannot be read.
+func readLines(name string) []string {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(b), "\n")
+}
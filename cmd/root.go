@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/github"
 )
 
 var currentUser *user.User
@@ -21,12 +22,24 @@ var rootCmd = &cobra.Command{
 	PersistentPreRun: persistentPreRun,
 }
 
+// skipConfigAnnotation marks commands that run without loading the configs.
+// Git hooks run without a terminal on every commit, so loading the configs would prompt
+// for missing settings, rewrite the global config and query the remote.
+const skipConfigAnnotation = "workflow.skip-config"
+
 // persistentPreRun runs settings before each command
-func persistentPreRun(*cobra.Command, []string) {
+func persistentPreRun(cmd *cobra.Command, _ []string) {
+	if _, ok := cmd.Annotations[skipConfigAnnotation]; ok {
+		return
+	}
+	config.init()
 	// TODO: Find a better place to initialize this.
 	config.Jira.APIURL = os.Getenv("WORKFLOW_ISSUE_API_URL")
 	config.Jira.WebURL = os.Getenv("WORKFLOW_ISSUE_API_URL")
 	config.Jira.AccountID = os.Getenv("JIRA_ACCOUNT_ID")
+	if token := os.Getenv(github.TokenEnvVar(config.GitHub.Host)); token != "" {
+		config.GitHub.Token = token
+	}
 	if git.RootDir() == "" {
 		failIfError(git.NotInitializedErr)
 	}
@@ -43,7 +56,6 @@ func init() {
 	currentUser, err = user.Current()
 	failIfError(err)
 
-	cobra.OnInitialize(config.init)
 	rootCmd.PersistentFlags().StringP("base", "B", "develop", "base branch to perform command on")
 	rootCmd.PersistentFlags().BoolP("force", "f", false, "force the command to run")
 }
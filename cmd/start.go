@@ -13,6 +13,17 @@ import (
 	"github.com/greganswer/workflow/jira"
 )
 
+// stashConfigKey is the config key for the default --stash-mode. Setting it stashes changes by default.
+const stashConfigKey = "start.stash"
+
+// Stash modes.
+const (
+	// stashApply reapplies the stashed changes on the new branch.
+	stashApply = "apply"
+	// stashPark leaves the stashed changes parked for the old branch.
+	stashPark = "park"
+)
+
 // startCmd represents the start command.
 var startCmd = &cobra.Command{
 	Use:    "start <issueID>",
@@ -24,6 +35,10 @@ var startCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(startCmd)
+	startCmd.Flags().BoolP("stack", "s", false, "start the branch on top of the current branch instead of the base branch")
+	startCmd.Flags().Bool("stash", false, "stash uncommitted changes, then apply them on the new branch")
+	startCmd.Flags().String("stash-mode", "", "what to do with the stashed changes: apply them on the new branch or park them (implies --stash)")
+	startCmd.Flags().BoolP("worktree", "w", false, "create the branch in a new worktree instead of switching branches")
 }
 
 func validateStartCmdArgs(_ *cobra.Command, args []string) error {
@@ -34,10 +49,36 @@ func validateStartCmdArgs(_ *cobra.Command, args []string) error {
 }
 
 func preRunStartCmd(cmd *cobra.Command, _ []string) {
-	force, _ := cmd.Flags().GetBool("force")
-	if !force && !git.RepoIsClean() {
-		failIfError(git.RepoIsDirtyErr)
+	// A new worktree leaves the current one untouched.
+	if worktree, _ := cmd.Flags().GetBool("worktree"); worktree {
+		return
+	}
+
+	mode := stashMode(cmd)
+	if mode == "" {
+		failIfDirty(cmd)
+		return
+	}
+	if mode != stashApply && mode != stashPark {
+		failIfError(fmt.Errorf("invalid stash mode %q. expected %s or %s", mode, stashApply, stashPark))
+	}
+}
+
+// stashMode returns the --stash-mode flag value, falling back to the config.
+// Returns an empty string if changes should not be stashed, which an explicit --stash=false always means.
+func stashMode(cmd *cobra.Command) string {
+	stash, _ := cmd.Flags().GetBool("stash")
+	if cmd.Flags().Changed("stash") && !stash {
+		return ""
+	}
+	if mode, _ := cmd.Flags().GetString("stash-mode"); mode != "" {
+		return mode
 	}
+	mode := config.getString(stashConfigKey)
+	if stash && mode == "" {
+		return stashApply
+	}
+	return mode
 }
 
 func runStartCmd(cmd *cobra.Command, args []string) {
@@ -46,14 +87,40 @@ func runStartCmd(cmd *cobra.Command, args []string) {
 	failIfError(err)
 
 	baseBranch, _ := cmd.Flags().GetString("base")
+	stack, _ := cmd.Flags().GetBool("stack")
+	if stack {
+		baseBranch, err = git.CurrentBranch()
+		failIfError(err)
+	}
+
+	worktree, _ := cmd.Flags().GetBool("worktree")
+	if worktree {
+		startInWorktree(issue, baseBranch, stack)
+		return
+	}
+
 	displayIssueAndBranchInfo(issue, baseBranch)
 	if !confirm("Create this branch") {
 		os.Exit(1)
 	}
 
-	failIfError(git.Checkout(baseBranch))
-	failIfError(git.Pull())
-	failIfError(git.CreateBranch(issue.BranchName()))
+	stash := stashChanges(issue, stashMode(cmd))
+	if stack {
+		tip, err := git.RevParse("HEAD")
+		failIfError(stash.explain(err))
+		failIfError(stash.explain(git.CreateBranch(issue.BranchName())))
+		failIfError(git.SetBranchParent(issue.BranchName(), baseBranch, tip))
+	} else {
+		failIfError(stash.explain(git.Checkout(baseBranch)))
+		failIfError(stash.explain(git.Pull()))
+		failIfError(stash.explain(git.CreateBranch(issue.BranchName())))
+	}
+
+	if stash != nil && stash.apply {
+		stash.restore()
+	} else if stash != nil {
+		stash.displayParked()
+	}
 	failIfError(jira.TransitionToInProgress(issue, config.Jira))
 	failIfError(jira.AssignUser(config.Jira.AccountID, issue, config.Jira))
 }
@@ -70,3 +137,66 @@ func displayIssueAndBranchInfo(i issues.Issue, base string) {
 
 	fmt.Println()
 }
+
+// stashedChanges are the changes stashed while starting an issue.
+type stashedChanges struct {
+	message string
+	branch  string
+	apply   bool
+}
+
+// stashChanges stashes the uncommitted changes if the mode is set and the repo is dirty.
+// Returns nil if nothing was stashed.
+func stashChanges(issue issues.Issue, mode string) *stashedChanges {
+	if mode == "" {
+		return nil
+	}
+
+	status, err := git.GetStatus()
+	failIfError(err)
+	if len(status.Conflicted) > 0 {
+		displayDirtyFiles(status)
+		failIfError(errors.New("conflicted files cannot be stashed. resolve them first"))
+	}
+	if status.IsClean() {
+		return nil
+	}
+
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	s := &stashedChanges{
+		message: fmt.Sprintf("workflow: changes on %s before starting %s", branch, issue.ID),
+		branch:  branch,
+		apply:   mode == stashApply,
+	}
+	fmt.Printf("Stashing uncommitted changes on %s...\n", branch)
+	failIfError(git.Stash(s.message))
+	return s
+}
+
+// explain adds where the stashed changes are to the error, if any were stashed.
+func (s *stashedChanges) explain(err error) error {
+	if err == nil || s == nil {
+		return err
+	}
+	return fmt.Errorf("%v. your changes are stashed as %q. restore them with 'git stash pop'", err, s.message)
+}
+
+// restore applies the stashed changes on the new branch. Conflicts are listed and the stash is kept.
+func (s *stashedChanges) restore() {
+	fmt.Println("Applying stashed changes...")
+	if err := git.StashPop(); err != nil {
+		status, statusErr := git.GetStatus()
+		warnIfError(statusErr)
+		displayDirtyFiles(status)
+		warnIfError(fmt.Errorf("the stashed changes conflict with the new branch. "+
+			"resolve the conflicts above, then run 'git stash drop' to remove the stash %q", s.message))
+	}
+}
+
+// displayParked tells the user how to get back the changes parked on the old branch.
+func (s *stashedChanges) displayParked() {
+	fmt.Printf("\nYour changes on %s are stashed as %q.\n", s.branch, s.message)
+	fmt.Printf("Restore them with 'git checkout %s && git stash pop'.\n", s.branch)
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+)
+
+// syncStrategyConfigKey is the config key for the default sync strategy.
+const syncStrategyConfigKey = "sync.strategy"
+
+// Sync strategies.
+const (
+	syncRebase = "rebase"
+	syncMerge  = "merge"
+)
+
+// syncCmd represents the sync command.
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Rebase or merge the base branch into the current branch",
+	Long: `Fetch the base branch, then rebase the current branch onto it or merge it into the current branch.
+Stacked branches are synced with their parent branch instead.
+If there are conflicts, resolve them and run "workflow sync --continue", or undo with "workflow sync --abort".`,
+	PreRun: preRunSyncCmd,
+	Run:    runSyncCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().String("strategy", "", "how to sync with the base branch: rebase or merge (default rebase)")
+	syncCmd.Flags().Bool("continue", false, "continue the sync after resolving conflicts")
+	syncCmd.Flags().Bool("abort", false, "abort the sync and restore the branch")
+	syncCmd.Flags().BoolP("push", "p", false, "push the synced branch, with lease after a rebase")
+}
+
+func preRunSyncCmd(cmd *cobra.Command, _ []string) {
+	continueSync, _ := cmd.Flags().GetBool("continue")
+	abort, _ := cmd.Flags().GetBool("abort")
+	if continueSync && abort {
+		failIfError(errors.New("--continue and --abort cannot be used together"))
+	}
+	// Conflicted files are expected while a sync is stopped.
+	if continueSync || abort {
+		return
+	}
+
+	strategy := syncStrategy(cmd)
+	if strategy != syncRebase && strategy != syncMerge {
+		failIfError(fmt.Errorf("invalid sync strategy %q. expected %s or %s", strategy, syncRebase, syncMerge))
+	}
+	failIfDirty(cmd)
+}
+
+// syncStrategy returns the --strategy flag value, falling back to the config and then rebase.
+func syncStrategy(cmd *cobra.Command) string {
+	if strategy, _ := cmd.Flags().GetString("strategy"); strategy != "" {
+		return strategy
+	}
+	if strategy := config.getString(syncStrategyConfigKey); strategy != "" {
+		return strategy
+	}
+	return syncRebase
+}
+
+func runSyncCmd(cmd *cobra.Command, _ []string) {
+	branch, err := git.CurrentBranch()
+	failIfError(err)
+
+	if abort, _ := cmd.Flags().GetBool("abort"); abort {
+		abortSync()
+		return
+	}
+
+	strategy := syncStrategy(cmd)
+	if continueSync, _ := cmd.Flags().GetBool("continue"); continueSync {
+		strategy = continueStoppedSync()
+	} else {
+		startSync(cmd, branch, strategy)
+	}
+
+	if push, _ := cmd.Flags().GetBool("push"); push {
+		// The branch name is only known again once a stopped rebase has finished.
+		branch, err = git.CurrentBranch()
+		failIfError(err)
+		if strategy == syncRebase {
+			failIfError(git.ForcePushWithLease(branch))
+		} else {
+			failIfError(git.Push(git.PushRemote(branch), branch))
+		}
+	}
+}
+
+// startSync fetches the base branch and rebases or merges it with the branch.
+// Stacked branches use their local parent branch, other branches the remote base branch.
+func startSync(cmd *cobra.Command, branch, strategy string) {
+	base := baseBranchFor(cmd, branch)
+	upstream := base
+	if git.BranchParent(branch) != base {
+		failIfError(git.Fetch(base))
+		upstream = git.Remote + "/" + base
+	}
+
+	if strategy == syncRebase {
+		fmt.Printf("Rebasing %s onto %s...\n", branch, upstream)
+		failIfConflicted(git.Rebase(upstream, branch))
+	} else {
+		fmt.Printf("Merging %s into %s...\n", upstream, branch)
+		failIfConflicted(git.Merge(upstream))
+	}
+}
+
+// continueStoppedSync continues the stopped rebase or merge and returns its strategy.
+func continueStoppedSync() string {
+	switch {
+	case git.RebaseInProgress():
+		failIfConflicted(git.RebaseContinue())
+		return syncRebase
+	case git.MergeInProgress():
+		failIfConflicted(git.MergeContinue())
+		return syncMerge
+	}
+	failIfError(errors.New("there is no rebase or merge to continue"))
+	return ""
+}
+
+// abortSync aborts the stopped rebase or merge.
+func abortSync() {
+	switch {
+	case git.RebaseInProgress():
+		failIfError(git.RebaseAbort())
+	case git.MergeInProgress():
+		failIfError(git.MergeAbort())
+	default:
+		failIfError(errors.New("there is no rebase or merge to abort"))
+	}
+}
+
+// failIfConflicted lists the conflicted files and exits if the rebase or merge stopped on conflicts.
+// Other errors fail as usual.
+func failIfConflicted(err error) {
+	if err == nil {
+		return
+	}
+	status, statusErr := git.GetStatus()
+	if statusErr != nil || len(status.Conflicted) == 0 {
+		failIfError(err)
+	}
+
+	displayDirtyFiles(git.Status{Conflicted: status.Conflicted})
+	fmt.Println("Resolve the conflicts and stage the files, then run 'workflow sync --continue'.")
+	fmt.Println("To undo the sync, run 'workflow sync --abort'.")
+	os.Exit(1)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/greganswer/workflow/git"
+	"github.com/greganswer/workflow/issues"
+	"github.com/greganswer/workflow/jira"
+)
+
+// worktreeDirConfigKey is the config key for the directory where issue worktrees are created.
+// Relative paths are relative to the project root.
+const worktreeDirConfigKey = "worktree.dir"
+
+// worktreesCmd represents the worktrees command.
+var worktreesCmd = &cobra.Command{
+	Use:   "worktrees",
+	Short: "List the worktrees and the status of their Jira issues",
+	Run:   runWorktreesCmd,
+}
+
+// worktreeCmd represents the worktree command.
+var worktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Manage the worktrees created for issues",
+}
+
+// worktreeRmCmd represents the worktree rm command.
+var worktreeRmCmd = &cobra.Command{
+	Use:   "rm <branch|path>...",
+	Short: "Remove worktrees by branch name or path",
+	Args:  validateWorktreeRmCmdArgs,
+	Run:   runWorktreeRmCmd,
+}
+
+func init() {
+	rootCmd.AddCommand(worktreesCmd)
+	rootCmd.AddCommand(worktreeCmd)
+	worktreeCmd.AddCommand(worktreeRmCmd)
+	worktreeRmCmd.Flags().BoolP("delete-branch", "d", false, "delete the branch of each removed worktree")
+}
+
+func validateWorktreeRmCmdArgs(_ *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return errors.New("requires at least one branch or path argument")
+	}
+	return nil
+}
+
+// worktreeDir returns the directory where issue worktrees are created.
+// Defaults to a "<project>-worktrees" directory next to the project root.
+func worktreeDir() string {
+	root := git.RootDir()
+	dir := config.getString(worktreeDirConfigKey)
+	if dir == "" {
+		return path.Join(path.Dir(root), path.Base(root)+"-worktrees")
+	}
+	if !path.IsAbs(dir) {
+		dir = path.Join(root, dir)
+	}
+	return dir
+}
+
+// startInWorktree creates the issue branch in a new worktree, leaving the current one untouched.
+// Stacked branches start from the current branch, other branches from the updated base branch.
+func startInWorktree(issue issues.Issue, baseBranch string, stack bool) {
+	worktreePath := path.Join(worktreeDir(), issue.BranchName())
+
+	displayIssueAndBranchInfo(issue, baseBranch)
+	fmt.Println(color.New(color.FgHiCyan).Sprint("    Worktree:"), worktreePath)
+	fmt.Println()
+	if !confirm("Create this worktree") {
+		os.Exit(1)
+	}
+
+	startPoint := baseBranch
+	if !stack {
+		failIfError(git.Fetch(baseBranch))
+		startPoint = git.Remote + "/" + baseBranch
+	}
+	failIfError(git.AddWorktree(worktreePath, issue.BranchName(), startPoint))
+	if stack {
+		tip, err := git.RevParse(startPoint)
+		failIfError(err)
+		failIfError(git.SetBranchParent(issue.BranchName(), baseBranch, tip))
+	}
+
+	failIfError(jira.TransitionToInProgress(issue, config.Jira))
+	failIfError(jira.AssignUser(config.Jira.AccountID, issue, config.Jira))
+	fmt.Printf("\nStart working with 'cd %s'\n", worktreePath)
+}
+
+func runWorktreesCmd(_ *cobra.Command, _ []string) {
+	worktrees, err := git.Worktrees()
+	failIfError(err)
+
+	var IDs []string
+	for _, w := range worktrees {
+		if ID := issues.ParseIDFromBranch(w.Branch); ID != "" {
+			IDs = append(IDs, ID)
+		}
+	}
+	list, errs := jira.GetIssues(IDs, config.Jira)
+	for _, err := range errs {
+		warnIfError(err)
+	}
+
+	statuses := make(map[string]string)
+	for _, i := range list {
+		statuses[strings.ToLower(i.ID)] = i.Status
+	}
+
+	cyan := color.New(color.FgHiCyan).SprintFunc()
+	fmt.Println()
+	title("  Worktrees:")
+	for _, w := range worktrees {
+		branch := w.Branch
+		if w.Detached {
+			branch = "(detached)"
+		}
+		fmt.Println("    "+w.Path, cyan(branch))
+		if status, ok := statuses[strings.ToLower(issues.ParseIDFromBranch(w.Branch))]; ok {
+			fmt.Println("      Status:", status)
+		}
+	}
+	fmt.Println()
+}
+
+func runWorktreeRmCmd(cmd *cobra.Command, args []string) {
+	force, _ := cmd.Flags().GetBool("force")
+	deleteBranch, _ := cmd.Flags().GetBool("delete-branch")
+
+	worktrees, err := git.Worktrees()
+	failIfError(err)
+
+	for _, arg := range args {
+		w := findWorktree(worktrees, arg)
+		if w == nil {
+			failIfError(fmt.Errorf("worktree not found: %s", arg))
+		}
+		if w.Path == worktrees[0].Path {
+			failIfError(fmt.Errorf("the main worktree cannot be removed: %s", w.Path))
+		}
+
+		fmt.Printf("Removing worktree %s...\n", w.Path)
+		failIfError(git.RemoveWorktree(w.Path, force))
+		if deleteBranch && w.Branch != "" {
+			failIfError(git.DeleteBranch(w.Branch))
+		}
+	}
+}
+
+// findWorktree returns the worktree with the branch name or path, or nil if there is none.
+func findWorktree(worktrees []git.Worktree, branchOrPath string) *git.Worktree {
+	abs, _ := filepath.Abs(branchOrPath)
+	for i, w := range worktrees {
+		if w.Branch == branchOrPath || w.Path == branchOrPath || w.Path == abs {
+			return &worktrees[i]
+		}
+	}
+	return nil
+}
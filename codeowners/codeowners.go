@@ -0,0 +1,156 @@
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Locations of the CODEOWNERS file relative to the project root, in the order GitHub searches them.
+// Reference: https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule maps a file pattern to its owners.
+type Rule struct {
+	Pattern string
+	// Owners are users ("@user"), teams ("@org/team") or email addresses.
+	Owners []string
+	re     *regexp.Regexp
+}
+
+// Rules of a CODEOWNERS file, in the order they appear.
+type Rules []Rule
+
+// Load the rules from the first CODEOWNERS file found in the project root.
+// Returns no rules if there is no CODEOWNERS file.
+func Load(root string) (Rules, error) {
+	for _, location := range Locations {
+		f, err := os.Open(path.Join(root, location))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return Parse(f)
+	}
+	return nil, nil
+}
+
+// Parse the rules of a CODEOWNERS file.
+func Parse(r io.Reader) (Rules, error) {
+	var rules Rules
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		re, err := patternToRegexp(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CODEOWNERS pattern on line %d", lineNumber)
+		}
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	return rules, scanner.Err()
+}
+
+// Owners of the file path. The last matching rule takes precedence.
+func (rules Rules) Owners(filePath string) []string {
+	filePath = strings.TrimPrefix(filePath, "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(filePath) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// Reviewers returns the users and teams owning the files, without the "@" prefix,
+// in the order they are first found. Email addresses and the excluded user are skipped.
+func (rules Rules) Reviewers(files []string, exclude string) []string {
+	var owners []string
+	for _, f := range files {
+		owners = append(owners, rules.Owners(f)...)
+	}
+	return MergeReviewers(exclude, owners)
+}
+
+// MergeReviewers combines the lists of users and teams into one, in the order they are first found.
+// Names are compared case-insensitively and lose their "@" prefix.
+// Blank names, email addresses and the excluded user are skipped.
+func MergeReviewers(exclude string, lists ...[]string) []string {
+	var reviewers []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, r := range list {
+			name := strings.TrimPrefix(strings.TrimSpace(r), "@")
+			key := strings.ToLower(name)
+			if name == "" || strings.Contains(name, "@") || seen[key] || strings.EqualFold(name, exclude) {
+				continue
+			}
+			seen[key] = true
+			reviewers = append(reviewers, name)
+		}
+	}
+	return reviewers
+}
+
+// patternToRegexp converts a gitignore style CODEOWNERS pattern to a regular expression.
+// Patterns containing a slash, other than a trailing one, are relative to the project root.
+// Patterns matching a directory match everything in it, except for "dir/*" which is not recursive.
+func patternToRegexp(pattern string) (*regexp.Regexp, error) {
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '*':
+			if i+1 < len(p) && p[i+1] == '*' {
+				if i+2 < len(p) && p[i+2] == '/' {
+					b.WriteString("(?:.*/)?")
+					i += 2
+				} else {
+					b.WriteString(".*")
+					i++
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(p) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(p[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if !strings.HasSuffix(p, "/*") || strings.HasSuffix(p, "/**") {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
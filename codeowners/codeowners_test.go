@@ -0,0 +1,100 @@
+package codeowners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOwners(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`# Default owners
+*                 @global-owner
+*.js              @js-owner # inline comment
+/build/logs/      @build-owner
+apps/             @apps-owner
+docs/*            @docs-owner
+**/tmp            @tmp-owner
+/scripts/**/*.sh  @ops
+src/config.yml    @config-owner
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		// The last matching rule wins.
+		{"README.md", "@global-owner"},
+		{"web/app.js", "@js-owner"},
+		{"docs/index.js", "@docs-owner"},
+
+		// Anchored patterns only match from the root.
+		{"build/logs/out.txt", "@build-owner"},
+		{"build/logs/2021/out.txt", "@build-owner"},
+		{"src/build/logs/out.txt", "@global-owner"},
+		{"src/config.yml", "@config-owner"},
+		{"other/src/config.yml", "@global-owner"},
+
+		// Directory patterns without a leading slash match anywhere.
+		{"apps/web/main.go", "@apps-owner"},
+		{"services/apps/main.go", "@apps-owner"},
+
+		// "docs/*" matches only the files directly in docs.
+		{"docs/getting-started.md", "@docs-owner"},
+		{"docs/build-app/troubleshooting.md", "@global-owner"},
+
+		// "**/tmp" matches a tmp directory at any depth.
+		{"tmp/cache", "@tmp-owner"},
+		{"a/b/tmp/cache", "@tmp-owner"},
+		{"a/tmpfile", "@global-owner"},
+
+		{"scripts/deploy.sh", "@ops"},
+		{"scripts/ci/nested/test.sh", "@ops"},
+		{"/scripts/deploy.sh", "@ops"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := strings.Join(rules.Owners(tt.path), " ")
+			if got != tt.want {
+				t.Errorf("Owners(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnersNoMatch(t *testing.T) {
+	rules, err := Parse(strings.NewReader("/docs/ @docs-owner\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := rules.Owners("main.go"); got != nil {
+		t.Errorf("Owners() = %v, want none", got)
+	}
+}
+
+func TestReviewers(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`
+*        @alice @org/backend
+*.md     @Bob docs@example.com
+/web/    @carol @ALICE
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got := rules.Reviewers([]string{"main.go", "README.md", "web/index.html"}, "bob")
+	want := []string{"alice", "org/backend", "carol"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reviewers() = %v, want %v", got, want)
+	}
+}
+
+func TestMergeReviewers(t *testing.T) {
+	got := MergeReviewers("me", []string{" dave", "", "Me", "x@example.com"}, []string{"@dave", "@org/web", "erin"})
+	want := []string{"dave", "org/web", "erin"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeReviewers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package commit
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// Config keys for the commit message.
+// The *_file key contains a path relative to the project root.
+const (
+	FormatConfigKey           = "commit.template"
+	FormatFileConfigKey       = "commit.template_file"
+	TypesConfigKey            = "commit.types"
+	MaxSubjectLengthConfigKey = "commit.max_subject_length"
+)
+
+// DefaultFormat is the default commit message template. It follows Conventional Commits.
+// Reference: https://www.conventionalcommits.org
+const DefaultFormat = "{{.Type}}({{.Issue.ID}}): {{.Subject}}"
+
+// DefaultMaxSubjectLength is the default maximum length of the first line of a commit message.
+const DefaultMaxSubjectLength = 72
+
+// categoryTypes are the default commit types of the issue categories.
+var categoryTypes = map[string]string{
+	issues.StoryCategory: "feat",
+	issues.BugCategory:   "fix",
+	issues.TaskCategory:  "chore",
+}
+
+// formatFuncs are the functions available to the commit message template.
+var formatFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// Data is the data available to the commit message template.
+type Data struct {
+	Issue  issues.Issue
+	Branch string
+	// Type of the commit. Example: feat
+	Type string
+	// Subject is the summary written by the user.
+	Subject string
+}
+
+// TypeForIssue returns the commit type of the issue.
+// The table maps Jira issue types to commit types and overrides the defaults:
+// Story is feat, Bug is fix and everything else is chore.
+func TypeForIssue(issue issues.Issue, table map[string]string) string {
+	for issueType, commitType := range table {
+		if strings.EqualFold(issueType, issue.Type) {
+			return commitType
+		}
+	}
+	return categoryTypes[issue.Category()]
+}
+
+// Render the commit message template with the data. An empty format uses the default.
+func Render(format string, data Data) (string, error) {
+	if strings.TrimSpace(format) == "" {
+		format = DefaultFormat
+	}
+	t, err := template.New("commit").Funcs(formatFuncs).Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid commit message template")
+	}
+
+	var b bytes.Buffer
+	if err = t.Execute(&b, data); err != nil {
+		return "", errors.Wrap(err, "render commit message failed")
+	}
+	return strings.TrimSpace(b.String()) + "\n", nil
+}
+
+// Clean removes the comment lines and surrounding blank lines from a message written in an editor.
+func Clean(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	cleaned := strings.TrimSpace(strings.Join(lines, "\n"))
+	if cleaned == "" {
+		return ""
+	}
+	return cleaned + "\n"
+}
+
+// Validate returns an error if the message is empty, its subject line is longer than maxLength,
+// or the subject is not separated from the body by a blank line.
+func Validate(message string, maxLength int) error {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	subject := lines[0]
+	if strings.TrimSpace(subject) == "" {
+		return errors.New("the commit message is empty")
+	}
+	if maxLength > 0 && len([]rune(subject)) > maxLength {
+		return fmt.Errorf("the commit subject is %d characters long. the maximum is %d", len([]rune(subject)), maxLength)
+	}
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		return errors.New("the commit subject must be followed by a blank line")
+	}
+	return nil
+}
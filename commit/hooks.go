@@ -0,0 +1,69 @@
+package commit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// exemptPrefixes are the subject prefixes of commits that do not need an issue ID.
+var exemptPrefixes = []string{"Merge ", "Revert ", "fixup! ", "squash! ", "amend! "}
+
+// Subject returns the first line of the message that is not blank or a comment.
+func Subject(message string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(line, "#") {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+// IsExempt returns true for merge, revert, fixup and squash commits, which do not need an issue ID.
+func IsExempt(message string) bool {
+	subject := Subject(message)
+	for _, prefix := range exemptPrefixes {
+		if strings.HasPrefix(subject, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrefixIssueID adds "ID: " to the start of the subject, unless the message already has an issue ID or is exempt.
+// A message without a subject, such as the template opened in the editor, gets the prefix on its first line.
+func PrefixIssueID(message, ID string) string {
+	if ID == "" || IsExempt(message) || issues.ParseIDFromCommit(Subject(message)) != "" {
+		return message
+	}
+
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+			lines[i] = ID + ": " + line
+			return strings.Join(lines, "\n")
+		}
+	}
+	return ID + ": \n" + message
+}
+
+// RequireIssueID returns an error if the subject does not start with an issue ID,
+// or has nothing after it, unless the message is exempt.
+// Empty messages are left for git to abort.
+func RequireIssueID(message string) error {
+	subject := Subject(message)
+	if subject == "" || IsExempt(message) {
+		return nil
+	}
+	ID := issues.ParseIDFromCommit(subject)
+	if ID == "" {
+		return fmt.Errorf("the commit subject %q does not start with an issue ID. example: ABC-123: Subject", subject)
+	}
+
+	rest := subject[strings.Index(subject, ID)+len(ID):]
+	if strings.Trim(rest, " :)!") == "" {
+		return fmt.Errorf("the commit subject %q has no summary after the issue ID", subject)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package git
+
+import (
+	"context"
+	"strings"
+)
+
+// Commit the staged changes with the message and return the output of git.
+// All stages the changes to tracked files first.
+func Commit(message string, all bool) (string, error) {
+	args := []string{"commit", "--file=-"}
+	if all {
+		args = append(args, "--all")
+	}
+	out, err := DefaultRunner.Run(context.Background(), strings.NewReader(message), "git", args...)
+	return string(out), err
+}
+
+// Editor returns the editor git uses for commit messages.
+// Reference: https://git-scm.com/docs/git-var#Documentation/git-var.txt-GITEDITOR
+func Editor() (string, error) {
+	out, err := output("var", "GIT_EDITOR")
+	return strings.TrimSpace(out), err
+}
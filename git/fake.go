@@ -0,0 +1,124 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// FakeRunner is an in-memory Runner which records the commands it is given
+// and returns scripted output instead of running them.
+type FakeRunner struct {
+	mu       sync.Mutex
+	commands []string
+	stdins   []string
+	results  map[string][]FakeResult
+}
+
+// FakeResult is the scripted result of a command.
+type FakeResult struct {
+	Output string
+	Err    error
+}
+
+// FakeExitError is a command failure with an exit code, like *exec.ExitError.
+type FakeExitError struct {
+	Code int
+}
+
+// Error message of the failure.
+func (e *FakeExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitCode of the failed command.
+func (e *FakeExitError) ExitCode() int {
+	return e.Code
+}
+
+// NewFakeRunner creates a FakeRunner without any scripted results.
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{results: make(map[string][]FakeResult)}
+}
+
+// TestingT is the part of *testing.T used by UseFakeRunner.
+type TestingT interface {
+	Helper()
+	Cleanup(func())
+}
+
+// UseFakeRunner replaces DefaultRunner with a new FakeRunner until the test and its subtests complete.
+func UseFakeRunner(t TestingT) *FakeRunner {
+	t.Helper()
+	fake := NewFakeRunner()
+	previous := DefaultRunner
+	DefaultRunner = fake
+	t.Cleanup(func() { DefaultRunner = previous })
+	return fake
+}
+
+// Script the output and error of the next run of the command line. Example: "git rev-parse HEAD"
+// Results are returned in the order they are scripted. The last one is repeated.
+// Commands without a scripted result succeed without output.
+func (f *FakeRunner) Script(command, output string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results[command] = append(f.results[command], FakeResult{Output: output, Err: err})
+}
+
+// Commands returns the command lines run so far, in order.
+func (f *FakeRunner) Commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.commands...)
+}
+
+// Stdins returns the standard input given to each command run so far, in order.
+func (f *FakeRunner) Stdins() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.stdins...)
+}
+
+// Run records the command and returns its scripted result.
+func (f *FakeRunner) Run(ctx context.Context, stdin io.Reader, name string, arg ...string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	input := ""
+	if stdin != nil {
+		b, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, err
+		}
+		input = string(b)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	command := strings.Join(append([]string{name}, arg...), " ")
+	f.commands = append(f.commands, command)
+	f.stdins = append(f.stdins, input)
+
+	results := f.results[command]
+	if len(results) == 0 {
+		return nil, nil
+	}
+	r := results[0]
+	if len(results) > 1 {
+		f.results[command] = results[1:]
+	}
+	return []byte(r.Output), r.Err
+}
+
+// Stream records the command and prints its scripted output.
+func (f *FakeRunner) Stream(ctx context.Context, name string, arg ...string) error {
+	out, err := f.Run(ctx, nil, name, arg...)
+	if len(out) > 0 {
+		fmt.Print(string(out))
+	}
+	return err
+}
@@ -2,68 +2,82 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 )
 
-// RepoIsDirtyErr is raised if the repository has unstaged changes.
-var RepoIsDirtyErr = fmt.Errorf("repository has unstaged changes")
+// RepoIsDirtyErr is raised if the repository has uncommitted changes or untracked files.
+var RepoIsDirtyErr = fmt.Errorf("repository has uncommitted changes")
 
 // NotInitializedErr is raised if the repository has not been initialized.
 var NotInitializedErr = fmt.Errorf("git repository has not been initialized")
 
 // Checkout branch by name.
 func Checkout(branch string) error {
-	return executeAndStream("git", "checkout", branch)
+	return stream("checkout", branch)
 }
 
 // CreateBranch creates a new git branch.
 func CreateBranch(name string) error {
-	return executeAndStream("git", "checkout", "-b", name)
+	return stream("checkout", "-b", name)
+}
+
+// DeleteBranch deletes a local git branch, even if it has not been merged locally.
+func DeleteBranch(name string) error {
+	return stream("branch", "-D", name)
+}
+
+// DeleteRemoteBranch deletes a branch from the configured remote.
+func DeleteRemoteBranch(name string) error {
+	return stream("push", Remote, "--delete", name)
 }
 
 // CurrentBranch returns the current branch for this Git repo.
 func CurrentBranch() (string, error) {
-	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
-	return strings.Trim(string(out), "\n"), err
+	out, err := output("rev-parse", "--abbrev-ref", "HEAD")
+	return strings.Trim(out, "\n"), err
 }
 
-// RepoIsClean returns false if there are changes in the repo.
+// RepoIsClean returns false if there are staged, unstaged, untracked or conflicted files in the repo.
 func RepoIsClean() bool {
-	return exec.Command("git", "diff", "--exit-code").Run() == nil
+	s, err := GetStatus()
+	return err == nil && s.IsClean()
 }
 
 // RootDir is the root directory of the Git project.
+// Returns an empty string outside of a Git project.
 // Reference: https://stackoverflow.com/a/957978
 func RootDir() string {
-	out, _ := exec.Command("git", "rev-parse", "--show-toplevel").CombinedOutput()
-	return strings.TrimSuffix(string(out), "\n")
+	out, _ := output("rev-parse", "--show-toplevel")
+	return strings.TrimSuffix(out, "\n")
 }
 
 // Pull gets new changes from the remote repo.
 func Pull() error {
-	return executeAndStream("git", "pull")
+	return stream("pull")
 }
 
-// Remote gets the remote project info.
-func remote() (string, error) {
-	out, err := exec.Command("git", "remote", "-v").Output()
-	return strings.Trim(string(out), "\n"), err
+// CommitSubjects returns the subject of each commit in the revision range, newest first.
+func CommitSubjects(revisionRange string) ([]string, error) {
+	out, err := output("log", "--format=%s", revisionRange)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := strings.Trim(out, "\n")
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
 }
 
-// ProjectName extracts the project name from the remote info.
-func ProjectName() (string, error) {
-	out, err := remote()
+// ChangedFiles returns the paths of the files changed on HEAD since it diverged from the base branch.
+func ChangedFiles(base string) ([]string, error) {
+	out, err := output("diff", "--name-only", base+"...HEAD")
 	if err != nil {
-		return "", nil
+		return nil, err
 	}
-	a := strings.Split(out, "/")
-	b := a[len(a)-2:]
-	c := strings.Join(b, "/")
-	d := strings.Split(c, "\n")
-	e := strings.TrimPrefix(d[len(d)-1], "origin")
-	f := strings.TrimSpace(e)
-	g := strings.TrimPrefix(f, "git@github.com:")
-
-	return strings.TrimSuffix(g, ".git (push)"), nil
+	trimmed := strings.Trim(out, "\n")
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
 }
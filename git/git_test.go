@@ -0,0 +1,64 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrentBranch(t *testing.T) {
+	fake := UseFakeRunner(t)
+	fake.Script("git rev-parse --abbrev-ref HEAD", "feature-abc-123-title\n", nil)
+
+	got, err := CurrentBranch()
+	if err != nil {
+		t.Fatalf("CurrentBranch() error = %v", err)
+	}
+	if want := "feature-abc-123-title"; got != want {
+		t.Errorf("CurrentBranch() = %q, want %q", got, want)
+	}
+	if cmds := fake.Commands(); len(cmds) != 1 || cmds[0] != "git rev-parse --abbrev-ref HEAD" {
+		t.Errorf("Commands() = %v", cmds)
+	}
+}
+
+func TestCurrentBranchError(t *testing.T) {
+	fake := UseFakeRunner(t)
+	fake.Script("git rev-parse --abbrev-ref HEAD", "", &FakeExitError{Code: 128})
+
+	if _, err := CurrentBranch(); err == nil {
+		t.Error("CurrentBranch() error = nil, want an error")
+	}
+}
+
+func TestCommitSubjects(t *testing.T) {
+	fake := UseFakeRunner(t)
+	fake.Script("git log --format=%s develop..HEAD", "ABC-2: Second\nABC-1: First\n", nil)
+	fake.Script("git log --format=%s main..develop", "", nil)
+
+	got, err := CommitSubjects("develop..HEAD")
+	if err != nil {
+		t.Fatalf("CommitSubjects() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "ABC-2: Second" || got[1] != "ABC-1: First" {
+		t.Errorf("CommitSubjects() = %q", got)
+	}
+
+	got, err = CommitSubjects("main..develop")
+	if err != nil || got != nil {
+		t.Errorf("CommitSubjects() = %q, %v, want no subjects", got, err)
+	}
+}
+
+func TestRepoIsClean(t *testing.T) {
+	fake := UseFakeRunner(t)
+	const command = "git status --porcelain=v2 --branch -z"
+	fake.Script(command, "# branch.head main\x00", nil)
+	fake.Script(command, "# branch.head main\x00? new.go\x00", nil)
+	fake.Script(command, "", errors.New("not a git repository"))
+
+	for i, want := range []bool{true, false, false} {
+		if got := RepoIsClean(); got != want {
+			t.Errorf("RepoIsClean() #%d = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -2,14 +2,15 @@ package git
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os/exec"
 )
 
 // executeAndStream executes a shell command and streams the output to the terminal.
 // Reference: https://stackoverflow.com/a/45957859
-func executeAndStream(name string, arg ...string) error {
-	c := exec.Command(name, arg...)
+func executeAndStream(ctx context.Context, name string, arg ...string) error {
+	c := exec.CommandContext(ctx, name, arg...)
 
 	// Setup stdout and stderr.
 	stdout, err := c.StdoutPipe()
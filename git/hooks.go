@@ -0,0 +1,16 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// HooksDir returns the absolute path of the hooks directory, respecting core.hooksPath.
+// Reference: https://git-scm.com/docs/githooks
+func HooksDir() (string, error) {
+	out, err := output("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(strings.TrimSpace(out))
+}
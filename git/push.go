@@ -0,0 +1,91 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RemoteBranch is a local branch compared with its copy on the remote it is pushed to.
+type RemoteBranch struct {
+	Remote string
+	Branch string
+	// Exists is false if the branch has not been pushed to the remote, or was deleted there.
+	Exists bool
+	// Ahead and Behind are the number of commits compared to the remote branch.
+	Ahead  int
+	Behind int
+	// Tracked is true if the remote branch is the upstream of the local branch.
+	Tracked bool
+}
+
+// Ref returns the remote-tracking ref of the branch. Example: origin/feature-abc-123-title
+func (b RemoteBranch) Ref() string {
+	return b.Remote + "/" + b.Branch
+}
+
+// NeedsPush returns true if the remote branch is missing or lacks commits of the local branch.
+func (b RemoteBranch) NeedsPush() bool {
+	return !b.Exists || b.Ahead > 0
+}
+
+// CompareRemoteBranch fetches the branch from its push remote and compares the local branch with it.
+func CompareRemoteBranch(branch string) (RemoteBranch, error) {
+	b := RemoteBranch{Remote: PushRemote(branch), Branch: branch}
+
+	// The remote is asked directly since a stale remote-tracking ref remains when the branch is deleted there.
+	// Reference: https://git-scm.com/docs/git-ls-remote#Documentation/git-ls-remote.txt---exit-code
+	err := run("ls-remote", "--exit-code", "--heads", b.Remote, "refs/heads/"+branch)
+	if ExitCode(err) == 2 {
+		return b, nil
+	}
+	if err != nil {
+		return b, err
+	}
+	b.Exists = true
+
+	if err := run("fetch", "--quiet", b.Remote, branch); err != nil {
+		return b, err
+	}
+
+	out, err := output("rev-list", "--left-right", "--count", branch+"..."+b.Ref())
+	if err != nil {
+		return b, err
+	}
+	counts := strings.Fields(out)
+	if len(counts) != 2 {
+		return b, fmt.Errorf("malformed git rev-list count: %q", out)
+	}
+	if b.Ahead, err = strconv.Atoi(counts[0]); err != nil {
+		return b, fmt.Errorf("malformed git rev-list count: %q", out)
+	}
+	if b.Behind, err = strconv.Atoi(counts[1]); err != nil {
+		return b, fmt.Errorf("malformed git rev-list count: %q", out)
+	}
+
+	upstream, _ := output("rev-parse", "--abbrev-ref", branch+"@{upstream}")
+	b.Tracked = strings.TrimSpace(upstream) == b.Ref()
+	return b, nil
+}
+
+// PushRemote returns the remote the branch is pushed to.
+// Uses the branch.<name>.pushRemote and remote.pushDefault git configs, falling back to the configured remote.
+// Reference: https://git-scm.com/docs/git-config#Documentation/git-config.txt-branchltnamegtpushRemote
+func PushRemote(branch string) string {
+	for _, key := range []string{"branch." + branch + ".pushRemote", "remote.pushDefault"} {
+		if out, err := output("config", "--get", key); err == nil && strings.TrimSpace(out) != "" {
+			return strings.TrimSpace(out)
+		}
+	}
+	return Remote
+}
+
+// Push the branch to the remote.
+func Push(remote, branch string) error {
+	return stream("push", remote, branch)
+}
+
+// PushSetUpstream pushes the branch to the remote and tracks the remote branch.
+func PushSetUpstream(remote, branch string) error {
+	return stream("push", "--set-upstream", remote, branch)
+}
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompareRemoteBranch(t *testing.T) {
+	const branch = "feature-abc-1-title"
+	tests := []struct {
+		name     string
+		lsErr    error
+		fetchErr error
+		counts   string
+		upstream string
+		want     RemoteBranch
+		wantErr  bool
+		wantPush bool
+	}{
+		{
+			name:     "up to date",
+			counts:   "0\t0\n",
+			upstream: "origin/" + branch + "\n",
+			want:     RemoteBranch{Remote: "origin", Branch: branch, Exists: true, Tracked: true},
+		},
+		{
+			name:     "ahead without upstream",
+			counts:   "2\t0\n",
+			want:     RemoteBranch{Remote: "origin", Branch: branch, Exists: true, Ahead: 2},
+			wantPush: true,
+		},
+		{
+			name:     "behind",
+			counts:   "1\t3\n",
+			upstream: "origin/" + branch + "\n",
+			want:     RemoteBranch{Remote: "origin", Branch: branch, Exists: true, Ahead: 1, Behind: 3, Tracked: true},
+			wantPush: true,
+		},
+		{
+			name:     "not pushed yet",
+			lsErr:    &FakeExitError{Code: 2},
+			want:     RemoteBranch{Remote: "origin", Branch: branch},
+			wantPush: true,
+		},
+		{
+			name:     "deleted on the remote with a stale tracking ref",
+			lsErr:    &FakeExitError{Code: 2},
+			counts:   "0\t0\n",
+			upstream: "origin/" + branch + "\n",
+			want:     RemoteBranch{Remote: "origin", Branch: branch},
+			wantPush: true,
+		},
+		{
+			name:     "remote unreachable",
+			lsErr:    &FakeExitError{Code: 128},
+			want:     RemoteBranch{Remote: "origin", Branch: branch},
+			wantErr:  true,
+			wantPush: true,
+		},
+		{
+			name:     "fetch failed",
+			fetchErr: errors.New("network unreachable"),
+			want:     RemoteBranch{Remote: "origin", Branch: branch, Exists: true},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := UseFakeRunner(t)
+			fake.Script("git ls-remote --exit-code --heads origin refs/heads/"+branch, "", tt.lsErr)
+			fake.Script("git fetch --quiet origin "+branch, "", tt.fetchErr)
+			fake.Script("git rev-list --left-right --count "+branch+"...origin/"+branch, tt.counts, nil)
+			fake.Script("git rev-parse --abbrev-ref "+branch+"@{upstream}", tt.upstream, nil)
+
+			got, err := CompareRemoteBranch(branch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CompareRemoteBranch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CompareRemoteBranch() = %+v, want %+v", got, tt.want)
+			}
+			if got.NeedsPush() != tt.wantPush {
+				t.Errorf("NeedsPush() = %v, want %v", got.NeedsPush(), tt.wantPush)
+			}
+		})
+	}
+}
+
+func TestCompareRemoteBranchPushRemote(t *testing.T) {
+	fake := UseFakeRunner(t)
+	fake.Script("git config --get branch.topic.pushRemote", "fork\n", nil)
+	fake.Script("git rev-list --left-right --count topic...fork/topic", "0\t0\n", nil)
+
+	got, err := CompareRemoteBranch("topic")
+	if err != nil {
+		t.Fatalf("CompareRemoteBranch() error = %v", err)
+	}
+	if got.Ref() != "fork/topic" {
+		t.Errorf("Ref() = %q, want fork/topic", got.Ref())
+	}
+}
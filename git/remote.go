@@ -0,0 +1,187 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RemoteConfigKey is the config key for the name of the remote used by workflow commands.
+const RemoteConfigKey = "git.remote"
+
+// DefaultRemote is the name of the remote used when none is configured.
+const DefaultRemote = "origin"
+
+// Remote is the name of the remote used to fetch, push and find the GitHub repo.
+var Remote = DefaultRemote
+
+// Remote URL protocols.
+const (
+	ProtocolSSH   = "ssh"
+	ProtocolHTTPS = "https"
+	ProtocolHTTP  = "http"
+	ProtocolGit   = "git"
+	ProtocolFile  = "file"
+)
+
+// RemoteURL is the parsed URL of a remote.
+type RemoteURL struct {
+	// Protocol is one of the Protocol constants.
+	Protocol string
+	// User of the URL, if any. Example: git
+	User string
+	// Host name of the URL. SSH aliases are resolved to the real host name by GetRemoteURL.
+	Host string
+	// Alias is the SSH host alias used in the URL, if it differs from Host.
+	Alias string
+	// Port of the URL, if any.
+	Port string
+	// Owner of the repo. Nested groups are separated by slashes, except in local paths
+	// where the owner is the directory containing the repo. Example: greganswer
+	Owner string
+	// Repo name without the ".git" suffix. Example: workflow
+	Repo string
+}
+
+// Project returns the "owner/repo" path of the remote. Example: greganswer/workflow
+func (r RemoteURL) Project() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// GetRemoteURL returns the parsed push URL of the named remote.
+// SSH host aliases are resolved with "ssh -G".
+func GetRemoteURL(name string) (RemoteURL, error) {
+	out, err := output("remote", "get-url", "--push", name)
+	if err != nil {
+		return RemoteURL{}, err
+	}
+	r, err := ParseRemoteURL(strings.TrimSpace(out))
+	if err != nil {
+		return RemoteURL{}, fmt.Errorf("remote %s: %w", name, err)
+	}
+	if r.Protocol == ProtocolSSH {
+		if host := sshHostName(r.Host); host != "" && !strings.EqualFold(host, r.Host) {
+			r.Alias, r.Host = r.Host, host
+		}
+	}
+	return r, nil
+}
+
+// sshHostNames caches the host names of SSH aliases, since resolving them runs "ssh".
+var sshHostNames = struct {
+	sync.Mutex
+	byAlias map[string]string
+}{byAlias: make(map[string]string)}
+
+// sshHostName returns the host name that SSH connects to for the alias, or an empty string if unknown.
+// Reference: https://man.openbsd.org/ssh#G
+func sshHostName(alias string) string {
+	sshHostNames.Lock()
+	defer sshHostNames.Unlock()
+	if host, ok := sshHostNames.byAlias[alias]; ok {
+		return host
+	}
+
+	var host string
+	if out, err := DefaultRunner.Run(context.Background(), nil, "ssh", "-G", alias); err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "hostname" {
+				host = fields[1]
+				break
+			}
+		}
+	}
+	sshHostNames.byAlias[alias] = host
+	return host
+}
+
+// ParseRemoteURL parses the URL of a remote. Supported forms:
+//
+//	https://host[:port]/owner/repo.git
+//	ssh://[user@]host[:port]/owner/repo.git
+//	git://host[:port]/owner/repo.git
+//	[user@]host:owner/repo.git
+//	file:///path/owner/repo.git and /path/owner/repo.git
+//
+// Reference: https://git-scm.com/docs/git-clone#_git_urls
+func ParseRemoteURL(rawURL string) (RemoteURL, error) {
+	if rawURL == "" {
+		return RemoteURL{}, fmt.Errorf("empty remote URL")
+	}
+
+	var r RemoteURL
+	var repoPath string
+	switch {
+	case strings.Contains(rawURL, "://"):
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return RemoteURL{}, fmt.Errorf("invalid remote URL %q: %w", rawURL, err)
+		}
+		switch u.Scheme {
+		case "ssh", "git+ssh", "ssh+git":
+			r.Protocol = ProtocolSSH
+		case ProtocolHTTPS, ProtocolHTTP, ProtocolGit, ProtocolFile:
+			r.Protocol = u.Scheme
+		default:
+			return RemoteURL{}, fmt.Errorf("unsupported remote URL protocol %q in %q", u.Scheme, rawURL)
+		}
+		if u.User != nil {
+			r.User = u.User.Username()
+		}
+		r.Host = u.Hostname()
+		r.Port = u.Port()
+		repoPath = u.Path
+	case isSCPLike(rawURL):
+		parts := strings.SplitN(rawURL, ":", 2)
+		r.Protocol = ProtocolSSH
+		r.Host = parts[0]
+		if i := strings.LastIndex(r.Host, "@"); i >= 0 {
+			r.User, r.Host = r.Host[:i], r.Host[i+1:]
+		}
+		repoPath = parts[1]
+	default:
+		r.Protocol = ProtocolFile
+		repoPath = rawURL
+	}
+
+	if r.Host == "" && r.Protocol != ProtocolFile {
+		return RemoteURL{}, fmt.Errorf("missing host in remote URL %q", rawURL)
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	i := strings.LastIndex(repoPath, "/")
+	if i <= 0 || i == len(repoPath)-1 {
+		return RemoteURL{}, fmt.Errorf("missing owner or repo in remote URL %q", rawURL)
+	}
+	r.Owner, r.Repo = repoPath[:i], repoPath[i+1:]
+	if r.Protocol == ProtocolFile {
+		// The directories above the owner are not part of a local repo's project.
+		r.Owner = r.Owner[strings.LastIndex(r.Owner, "/")+1:]
+	}
+	return r, nil
+}
+
+// isSCPLike returns true for the scp-like "[user@]host:path" syntax.
+// Git treats the URL as a local path if a slash comes before the first colon.
+func isSCPLike(rawURL string) bool {
+	colon := strings.Index(rawURL, ":")
+	slash := strings.Index(rawURL, "/")
+	return colon > 0 && (slash < 0 || colon < slash)
+}
+
+// RemoteHost returns the host name of the configured remote. Example: github.com
+func RemoteHost() (string, error) {
+	r, err := GetRemoteURL(Remote)
+	return r.Host, err
+}
+
+// ProjectName returns the "owner/repo" path of the configured remote. Example: greganswer/workflow
+func ProjectName() (string, error) {
+	r, err := GetRemoteURL(Remote)
+	if err != nil {
+		return "", err
+	}
+	return r.Project(), nil
+}
@@ -0,0 +1,96 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Runner runs external commands, such as git and gh.
+// The command is stopped when the context is done.
+// Replace DefaultRunner with a FakeRunner to avoid running real commands.
+type Runner interface {
+	// Run the command with the optional standard input and return its standard output.
+	Run(ctx context.Context, stdin io.Reader, name string, arg ...string) ([]byte, error)
+	// Stream the output of the command to the terminal.
+	Stream(ctx context.Context, name string, arg ...string) error
+}
+
+// DefaultRunner is the Runner used by the git and github packages.
+var DefaultRunner Runner = ExecRunner{}
+
+// ExecRunner runs commands with os/exec.
+type ExecRunner struct{}
+
+// CommandError is returned when a command fails. It includes the standard error output.
+type CommandError struct {
+	Command string
+	Stderr  string
+	Err     error
+}
+
+// Error message of the failed command.
+func (e *CommandError) Error() string {
+	if e.Stderr == "" {
+		return fmt.Sprintf("%s: %v", e.Command, e.Err)
+	}
+	return fmt.Sprintf("%s: %v: %s", e.Command, e.Err, e.Stderr)
+}
+
+// Unwrap returns the underlying error.
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// Run the command with the optional standard input and return its standard output.
+func (ExecRunner) Run(ctx context.Context, stdin io.Reader, name string, arg ...string) ([]byte, error) {
+	c := exec.CommandContext(ctx, name, arg...)
+	c.Stdin = stdin
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+
+	out, err := c.Output()
+	if err != nil {
+		err = &CommandError{
+			Command: strings.Join(append([]string{name}, arg...), " "),
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+	return out, err
+}
+
+// Stream the output of the command to the terminal.
+func (ExecRunner) Stream(ctx context.Context, name string, arg ...string) error {
+	return executeAndStream(ctx, name, arg...)
+}
+
+// ExitCode returns the exit code of the failed command, or -1 if the error has none.
+func ExitCode(err error) int {
+	var e interface{ ExitCode() int }
+	if errors.As(err, &e) {
+		return e.ExitCode()
+	}
+	return -1
+}
+
+// output runs git and returns its standard output.
+func output(arg ...string) (string, error) {
+	out, err := DefaultRunner.Run(context.Background(), nil, "git", arg...)
+	return string(out), err
+}
+
+// run git and return whether it succeeded.
+func run(arg ...string) error {
+	_, err := output(arg...)
+	return err
+}
+
+// stream runs git and streams its output to the terminal.
+func stream(arg ...string) error {
+	return DefaultRunner.Stream(context.Background(), "git", arg...)
+}
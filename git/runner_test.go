@@ -0,0 +1,52 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUseFakeRunner(t *testing.T) {
+	previous := DefaultRunner
+	t.Run("subtest", func(t *testing.T) {
+		fake := UseFakeRunner(t)
+		if DefaultRunner != fake {
+			t.Errorf("DefaultRunner = %T, want the fake", DefaultRunner)
+		}
+	})
+	if DefaultRunner != previous {
+		t.Errorf("DefaultRunner = %T after the test, want %T", DefaultRunner, previous)
+	}
+}
+
+func TestFakeRunnerCancelledContext(t *testing.T) {
+	fake := NewFakeRunner()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := fake.Run(ctx, nil, "git", "status"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if len(fake.Commands()) != 0 {
+		t.Errorf("Commands() = %v, want none", fake.Commands())
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"no exit code", errors.New("failed"), -1},
+		{"exit error", &FakeExitError{Code: 1}, 1},
+		{"wrapped exit error", &CommandError{Command: "git", Err: &FakeExitError{Code: 128}}, 128},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
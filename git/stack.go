@@ -0,0 +1,89 @@
+package git
+
+import "strings"
+
+// parentConfigKey is the branch config key storing the parent of a stacked branch.
+// The key is removed by git together with the branch.
+const parentConfigKey = "workflow-parent"
+
+// parentTipConfigKey is the branch config key storing the commit of the parent the stacked branch is based on.
+// It tells which commits belong to the branch after the parent is rebased, squash merged or deleted.
+const parentTipConfigKey = "workflow-parent-tip"
+
+// SetBranchParent records the parent branch of a stacked branch, and the commit of the parent
+// the branch is based on, in the local git config.
+func SetBranchParent(branch, parent, tip string) error {
+	if err := run("config", "branch."+branch+"."+parentConfigKey, parent); err != nil {
+		return err
+	}
+	return run("config", "branch."+branch+"."+parentTipConfigKey, tip)
+}
+
+// UnsetBranchParent removes the recorded parent of the branch so it is no longer stacked.
+func UnsetBranchParent(branch string) error {
+	// The tip is missing for branches stacked before it was recorded.
+	_ = run("config", "--unset", "branch."+branch+"."+parentTipConfigKey)
+	return run("config", "--unset", "branch."+branch+"."+parentConfigKey)
+}
+
+// BranchParent returns the recorded parent of the branch, or an empty string if it is not stacked.
+func BranchParent(branch string) string {
+	out, _ := output("config", "--get", "branch."+branch+"."+parentConfigKey)
+	return strings.TrimSpace(out)
+}
+
+// BranchParentTip returns the recorded commit of the parent the stacked branch is based on,
+// or an empty string if none is recorded.
+func BranchParentTip(branch string) string {
+	out, _ := output("config", "--get", "branch."+branch+"."+parentTipConfigKey)
+	return strings.TrimSpace(out)
+}
+
+// StackedBranches maps each stacked branch to its recorded parent.
+func StackedBranches() (map[string]string, error) {
+	stacks := make(map[string]string)
+	out, err := output("config", "--get-regexp", `^branch\..*\.`+parentConfigKey+`$`)
+	if ExitCode(err) == 1 {
+		// No stacked branches.
+		return stacks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSuffix(strings.TrimPrefix(parts[0], "branch."), "."+parentConfigKey)
+		stacks[key] = parts[1]
+	}
+	return stacks, nil
+}
+
+// BranchExists returns true if the local branch exists.
+func BranchExists(name string) bool {
+	return run("rev-parse", "--verify", "--quiet", "refs/heads/"+name) == nil
+}
+
+// RevParse returns the commit SHA of the ref.
+func RevParse(ref string) (string, error) {
+	out, err := output("rev-parse", "--verify", ref)
+	return strings.TrimSpace(out), err
+}
+
+// Rebase the branch onto the upstream branch.
+func Rebase(upstream, branch string) error {
+	return stream("rebase", upstream, branch)
+}
+
+// RebaseOnto moves the commits of the branch that are not in upstream onto newBase.
+func RebaseOnto(newBase, upstream, branch string) error {
+	return stream("rebase", "--onto", newBase, upstream, branch)
+}
+
+// ForcePushWithLease pushes the rewritten branch to the configured remote unless the remote branch has unexpected commits.
+func ForcePushWithLease(branch string) error {
+	return stream("push", "--force-with-lease", Remote, branch)
+}
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+// useTempRepo runs the rest of the test in a new empty git repo.
+func useTempRepo(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	if out, err := exec.Command("git", "init", "--quiet").CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, out)
+	}
+}
+
+func TestStackedBranches(t *testing.T) {
+	useTempRepo(t)
+	if err := SetBranchParent("feature-abc-2-child", "feature-abc-1-parent", "1111111"); err != nil {
+		t.Fatalf("SetBranchParent() error = %v", err)
+	}
+	if err := SetBranchParent("feature-abc-3-grandchild", "feature-abc-2-child", "2222222"); err != nil {
+		t.Fatalf("SetBranchParent() error = %v", err)
+	}
+
+	got, err := StackedBranches()
+	if err != nil {
+		t.Fatalf("StackedBranches() error = %v", err)
+	}
+	want := map[string]string{
+		"feature-abc-2-child":      "feature-abc-1-parent",
+		"feature-abc-3-grandchild": "feature-abc-2-child",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StackedBranches() = %v, want %v", got, want)
+	}
+}
+
+func TestStackedBranchesNone(t *testing.T) {
+	useTempRepo(t)
+
+	got, err := StackedBranches()
+	if err != nil {
+		t.Fatalf("StackedBranches() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("StackedBranches() = %v, want none", got)
+	}
+}
+
+func TestBranchParent(t *testing.T) {
+	useTempRepo(t)
+	if err := SetBranchParent("child", "parent", "1111111"); err != nil {
+		t.Fatalf("SetBranchParent() error = %v", err)
+	}
+
+	if got := BranchParent("child"); got != "parent" {
+		t.Errorf("BranchParent(child) = %q, want parent", got)
+	}
+	if got := BranchParentTip("child"); got != "1111111" {
+		t.Errorf("BranchParentTip(child) = %q, want 1111111", got)
+	}
+	if got := BranchParent("other"); got != "" {
+		t.Errorf("BranchParent(other) = %q, want empty", got)
+	}
+
+	if err := UnsetBranchParent("child"); err != nil {
+		t.Fatalf("UnsetBranchParent() error = %v", err)
+	}
+	if got := BranchParent("child"); got != "" {
+		t.Errorf("BranchParent(child) after unset = %q, want empty", got)
+	}
+	if got := BranchParentTip("child"); got != "" {
+		t.Errorf("BranchParentTip(child) after unset = %q, want empty", got)
+	}
+}
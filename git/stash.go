@@ -0,0 +1,12 @@
+package git
+
+// Stash the uncommitted changes and untracked files with the message.
+func Stash(message string) error {
+	return stream("stash", "push", "--include-untracked", "--message", message)
+}
+
+// StashPop applies the most recent stash and drops it.
+// The stash is kept if it cannot be applied cleanly.
+func StashPop() error {
+	return stream("stash", "pop")
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Status of the working tree and current branch.
+type Status struct {
+	Branch   string
+	Upstream string
+	// Ahead and Behind are the number of commits compared to the upstream branch.
+	Ahead  int
+	Behind int
+
+	Staged     []string
+	Unstaged   []string
+	Untracked  []string
+	Conflicted []string
+}
+
+// IsClean returns true if there are no staged, unstaged, untracked or conflicted files.
+func (s Status) IsClean() bool {
+	return len(s.Staged) == 0 && len(s.Unstaged) == 0 && len(s.Untracked) == 0 && len(s.Conflicted) == 0
+}
+
+// HasUpstream returns true if the branch tracks a remote branch.
+func (s Status) HasUpstream() bool {
+	return s.Upstream != ""
+}
+
+// GetStatus returns the status of the working tree and current branch.
+// Reference: https://git-scm.com/docs/git-status#_porcelain_format_version_2
+func GetStatus() (Status, error) {
+	out, err := output("status", "--porcelain=v2", "--branch", "-z")
+	if err != nil {
+		return Status{}, err
+	}
+	return ParseStatus(out)
+}
+
+// ParseStatus parses the output of "git status --porcelain=v2 --branch -z".
+func ParseStatus(out string) (Status, error) {
+	var s Status
+	entries := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		switch entry[0] {
+		case '#':
+			if err := s.parseHeader(entry); err != nil {
+				return s, err
+			}
+		case '1':
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) < 9 {
+				return s, fmt.Errorf("malformed git status entry: %q", entry)
+			}
+			s.addChange(fields[1], fields[8])
+		case '2':
+			// Renamed and copied entries are followed by the original path.
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) < 10 {
+				return s, fmt.Errorf("malformed git status entry: %q", entry)
+			}
+			s.addChange(fields[1], fields[9])
+			i++
+		case 'u':
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) < 11 {
+				return s, fmt.Errorf("malformed git status entry: %q", entry)
+			}
+			s.Conflicted = append(s.Conflicted, fields[10])
+		case '?':
+			s.Untracked = append(s.Untracked, strings.TrimPrefix(entry, "? "))
+		case '!':
+			// Ignored files are not dirty.
+		default:
+			return s, fmt.Errorf("unknown git status entry: %q", entry)
+		}
+	}
+	return s, nil
+}
+
+// parseHeader parses a "# branch.*" header line.
+func (s *Status) parseHeader(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return nil
+	}
+	switch fields[1] {
+	case "branch.head":
+		s.Branch = fields[2]
+	case "branch.upstream":
+		s.Upstream = fields[2]
+	case "branch.ab":
+		if len(fields) < 4 {
+			return fmt.Errorf("malformed git status header: %q", line)
+		}
+		var err error
+		if s.Ahead, err = strconv.Atoi(strings.TrimPrefix(fields[2], "+")); err != nil {
+			return fmt.Errorf("malformed git status header: %q", line)
+		}
+		if s.Behind, err = strconv.Atoi(strings.TrimPrefix(fields[3], "-")); err != nil {
+			return fmt.Errorf("malformed git status header: %q", line)
+		}
+	}
+	return nil
+}
+
+// addChange adds the path to the staged and unstaged files based on its XY status code.
+func (s *Status) addChange(xy, path string) {
+	if len(xy) != 2 {
+		return
+	}
+	if xy[0] != '.' {
+		s.Staged = append(s.Staged, path)
+	}
+	if xy[1] != '.' {
+		s.Unstaged = append(s.Unstaged, path)
+	}
+}
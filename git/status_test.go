@@ -0,0 +1,69 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStatus(t *testing.T) {
+	fake := UseFakeRunner(t)
+	out := "# branch.oid 1234567890abcdef\x00" +
+		"# branch.head feature-abc-1-title\x00" +
+		"# branch.upstream origin/feature-abc-1-title\x00" +
+		"# branch.ab +2 -1\x00" +
+		"1 M. N... 100644 100644 100644 aaa bbb staged.go\x00" +
+		"1 .M N... 100644 100644 100644 aaa bbb unstaged.go\x00" +
+		"1 MM N... 100644 100644 100644 aaa bbb both.go\x00" +
+		"2 R. N... 100644 100644 100644 aaa bbb R100 renamed.go\x00old.go\x00" +
+		"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflicted.go\x00" +
+		"? untracked.go\x00" +
+		"! ignored.go\x00"
+	fake.Script("git status --porcelain=v2 --branch -z", out, nil)
+
+	got, err := GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	want := Status{
+		Branch:     "feature-abc-1-title",
+		Upstream:   "origin/feature-abc-1-title",
+		Ahead:      2,
+		Behind:     1,
+		Staged:     []string{"staged.go", "both.go", "renamed.go"},
+		Unstaged:   []string{"unstaged.go", "both.go"},
+		Untracked:  []string{"untracked.go"},
+		Conflicted: []string{"conflicted.go"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetStatus() = %+v, want %+v", got, want)
+	}
+	if got.IsClean() {
+		t.Error("IsClean() = true, want false")
+	}
+}
+
+func TestGetStatusClean(t *testing.T) {
+	fake := UseFakeRunner(t)
+	fake.Script("git status --porcelain=v2 --branch -z", "# branch.oid 123\x00# branch.head main\x00", nil)
+
+	got, err := GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus() error = %v", err)
+	}
+	if !got.IsClean() || got.HasUpstream() {
+		t.Errorf("GetStatus() = %+v, want clean without upstream", got)
+	}
+}
+
+func TestParseStatusErrors(t *testing.T) {
+	tests := []string{
+		"# branch.ab +x -1\x00",
+		"1 M. N...\x00",
+		"z unknown\x00",
+	}
+	for _, out := range tests {
+		if _, err := ParseStatus(out); err == nil {
+			t.Errorf("ParseStatus(%q) error = nil, want an error", out)
+		}
+	}
+}
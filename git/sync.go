@@ -0,0 +1,52 @@
+package git
+
+import (
+	"os"
+	"strings"
+)
+
+// Merge the branch into the current branch.
+func Merge(branch string) error {
+	return stream("merge", "--no-edit", branch)
+}
+
+// RebaseInProgress returns true if a rebase has stopped, for example on a conflict.
+func RebaseInProgress() bool {
+	return gitPathExists("rebase-merge") || gitPathExists("rebase-apply")
+}
+
+// MergeInProgress returns true if a merge has stopped, for example on a conflict.
+func MergeInProgress() bool {
+	return gitPathExists("MERGE_HEAD")
+}
+
+// RebaseContinue continues the stopped rebase, keeping the commit messages.
+func RebaseContinue() error {
+	return stream("-c", "core.editor=true", "rebase", "--continue")
+}
+
+// RebaseAbort aborts the stopped rebase and restores the original branch.
+func RebaseAbort() error {
+	return stream("rebase", "--abort")
+}
+
+// MergeContinue commits the stopped merge with the prepared message.
+func MergeContinue() error {
+	return stream("commit", "--no-edit")
+}
+
+// MergeAbort aborts the stopped merge and restores the state before it.
+func MergeAbort() error {
+	return stream("merge", "--abort")
+}
+
+// gitPathExists returns true if the path inside the .git directory exists.
+// Reference: https://git-scm.com/docs/git-rev-parse#Documentation/git-rev-parse.txt---git-pathltpathgt
+func gitPathExists(name string) bool {
+	out, err := output("rev-parse", "--git-path", name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(strings.TrimSpace(out))
+	return err == nil
+}
@@ -0,0 +1,72 @@
+package git
+
+import "strings"
+
+// Worktree is a working tree attached to the repository.
+type Worktree struct {
+	Path     string
+	HEAD     string
+	Branch   string
+	Bare     bool
+	Detached bool
+}
+
+// Worktrees returns the working trees of the repository, starting with the main one.
+// Reference: https://git-scm.com/docs/git-worktree#_porcelain_format
+func Worktrees() ([]Worktree, error) {
+	out, err := output("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(out), nil
+}
+
+// parseWorktrees parses the output of "git worktree list --porcelain".
+func parseWorktrees(out string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(out), "\n\n") {
+		var w Worktree
+		for _, line := range strings.Split(block, "\n") {
+			kv := strings.SplitN(line, " ", 2)
+			value := ""
+			if len(kv) == 2 {
+				value = kv[1]
+			}
+			switch kv[0] {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.HEAD = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				w.Bare = true
+			case "detached":
+				w.Detached = true
+			}
+		}
+		if w.Path != "" {
+			worktrees = append(worktrees, w)
+		}
+	}
+	return worktrees
+}
+
+// AddWorktree creates the branch from the start point and checks it out in a new working tree at the path.
+// The branch does not track the start point.
+func AddWorktree(path, branch, startPoint string) error {
+	return stream("worktree", "add", "--no-track", "-b", branch, path, startPoint)
+}
+
+// RemoveWorktree removes the working tree at the path. Force removes it even if it has changes.
+func RemoveWorktree(path string, force bool) error {
+	if force {
+		return stream("worktree", "remove", "--force", path)
+	}
+	return stream("worktree", "remove", path)
+}
+
+// Fetch the branch from the configured remote.
+func Fetch(branch string) error {
+	return stream("fetch", Remote, branch)
+}
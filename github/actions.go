@@ -0,0 +1,104 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WorkflowRun is the data structure for a GitHub Actions workflow run from GitHub's JSON API response.
+type WorkflowRun struct {
+	ID         int64     `json:"id"`
+	Status     string    `json:"status"`
+	Conclusion string    `json:"conclusion"`
+	URL        string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Job is the data structure for a job of a workflow run from GitHub's JSON API response.
+type Job struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	URL         string     `json:"html_url"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+// State of the workflow run, normalized like checks.
+func (r *WorkflowRun) State() string {
+	return checkRunState(r.Status, r.Conclusion)
+}
+
+// State of the job, normalized like checks.
+func (j Job) State() string {
+	return checkRunState(j.Status, j.Conclusion)
+}
+
+// DispatchWorkflow triggers a workflow_dispatch event for the workflow (file name or ID) on the branch.
+// Reference: https://docs.github.com/en/rest/reference/actions#create-a-workflow-dispatch-event
+func (c *Client) DispatchWorkflow(repo, workflow, branch string, inputs map[string]string) error {
+	reqBody := map[string]interface{}{"ref": branch}
+	if len(inputs) > 0 {
+		reqBody["inputs"] = inputs
+	}
+	return c.do("POST", fmt.Sprintf("repos/%s/actions/workflows/%s/dispatches", repo, url.PathEscape(workflow)), reqBody, nil)
+}
+
+// FindDispatchedRun returns the most recent workflow_dispatch run of the workflow on the branch
+// created at or after the time, or nil if it has not been created yet.
+// Reference: https://docs.github.com/en/rest/reference/actions#list-workflow-runs
+func (c *Client) FindDispatchedRun(repo, workflow, branch string, since time.Time) (*WorkflowRun, error) {
+	q := url.Values{}
+	q.Set("event", "workflow_dispatch")
+	q.Set("branch", branch)
+	q.Set("per_page", "10")
+
+	var data struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("repos/%s/actions/workflows/%s/runs?%s", repo, url.PathEscape(workflow), q.Encode())
+	if err := c.do("GET", path, nil, &data); err != nil {
+		return nil, err
+	}
+	for i := range data.WorkflowRuns {
+		if !data.WorkflowRuns[i].CreatedAt.Before(since) {
+			return &data.WorkflowRuns[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// WorkflowRun returns the workflow run.
+// Reference: https://docs.github.com/en/rest/reference/actions#get-a-workflow-run
+func (c *Client) WorkflowRun(repo string, ID int64) (*WorkflowRun, error) {
+	var run WorkflowRun
+	err := c.do("GET", fmt.Sprintf("repos/%s/actions/runs/%d", repo, ID), nil, &run)
+	return &run, err
+}
+
+// Jobs returns the jobs of the workflow run.
+// Reference: https://docs.github.com/en/rest/reference/actions#list-jobs-for-a-workflow-run
+func (c *Client) Jobs(repo string, runID int64) ([]Job, error) {
+	var data struct {
+		Jobs []Job `json:"jobs"`
+	}
+	err := c.do("GET", fmt.Sprintf("repos/%s/actions/runs/%d/jobs?per_page=100", repo, runID), nil, &data)
+	return data.Jobs, err
+}
+
+// JobLogTail returns the last lines of the job's logs.
+// Reference: https://docs.github.com/en/rest/reference/actions#download-job-logs-for-a-workflow-run
+func (c *Client) JobLogTail(repo string, jobID int64, lines int) ([]string, error) {
+	logs, err := c.getText(fmt.Sprintf("repos/%s/actions/jobs/%d/logs", repo, jobID))
+	if err != nil {
+		return nil, err
+	}
+	all := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	if len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	return all, nil
+}
@@ -0,0 +1,325 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Normalized check states.
+const (
+	CheckPending = "pending"
+	CheckSuccess = "success"
+	CheckFailure = "failure"
+	// CheckNone is the overall state when no checks have been reported yet.
+	CheckNone = "none"
+)
+
+// Check is a check run or commit status on a commit.
+type Check struct {
+	Name        string
+	State       string
+	URL         string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	// Required is true if the check must pass before the PR can be merged.
+	Required bool
+}
+
+// Duration of the check. Checks that are still running are measured until now.
+func (c Check) Duration() time.Duration {
+	if c.StartedAt == nil {
+		return 0
+	}
+	end := time.Now()
+	if c.CompletedAt != nil {
+		end = *c.CompletedAt
+	}
+	return end.Sub(*c.StartedAt).Round(time.Second)
+}
+
+// checkRunsResponse is the data structure for check runs from GitHub's JSON API response.
+type checkRunsResponse struct {
+	CheckRuns []struct {
+		Name        string     `json:"name"`
+		Status      string     `json:"status"`
+		Conclusion  string     `json:"conclusion"`
+		HTMLURL     string     `json:"html_url"`
+		DetailsURL  string     `json:"details_url"`
+		StartedAt   *time.Time `json:"started_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	} `json:"check_runs"`
+}
+
+// statusResponse is the data structure for the combined status from GitHub's JSON API response.
+type statusResponse struct {
+	Statuses []struct {
+		Context   string     `json:"context"`
+		State     string     `json:"state"`
+		TargetURL string     `json:"target_url"`
+		CreatedAt *time.Time `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at"`
+	} `json:"statuses"`
+}
+
+// Checks returns the check runs and commit statuses for the ref in the repo ("owner/name").
+// Reference: https://docs.github.com/en/rest/reference/checks#list-check-runs-for-a-git-reference
+// Reference: https://docs.github.com/en/rest/reference/repos#get-the-combined-status-for-a-specific-reference
+func (c *Client) Checks(repo, ref string) ([]Check, error) {
+	var runs checkRunsResponse
+	if err := c.do("GET", fmt.Sprintf("repos/%s/commits/%s/check-runs?per_page=100", repo, ref), nil, &runs); err != nil {
+		return nil, err
+	}
+
+	var status statusResponse
+	if err := c.do("GET", fmt.Sprintf("repos/%s/commits/%s/status?per_page=100", repo, ref), nil, &status); err != nil {
+		return nil, err
+	}
+
+	var checks []Check
+	for _, r := range runs.CheckRuns {
+		URL := r.HTMLURL
+		if URL == "" {
+			URL = r.DetailsURL
+		}
+		checks = append(checks, Check{
+			Name:        r.Name,
+			State:       checkRunState(r.Status, r.Conclusion),
+			URL:         URL,
+			StartedAt:   r.StartedAt,
+			CompletedAt: r.CompletedAt,
+		})
+	}
+
+	for _, s := range status.Statuses {
+		check := Check{
+			Name:      s.Context,
+			State:     commitStatusState(s.State),
+			URL:       s.TargetURL,
+			StartedAt: s.CreatedAt,
+		}
+		if check.State != CheckPending {
+			check.CompletedAt = s.UpdatedAt
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// ChecksState returns the overall state of the checks. Any failure fails them all.
+// Returns CheckNone if there are no checks, for example before CI has started.
+func ChecksState(checks []Check) string {
+	if len(checks) == 0 {
+		return CheckNone
+	}
+	state := CheckSuccess
+	for _, c := range checks {
+		switch c.State {
+		case CheckFailure:
+			return CheckFailure
+		case CheckPending:
+			state = CheckPending
+		}
+	}
+	return state
+}
+
+// checkRunState normalizes the status and conclusion of a check run.
+func checkRunState(status, conclusion string) string {
+	if status != "completed" {
+		return CheckPending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return CheckSuccess
+	default:
+		return CheckFailure
+	}
+}
+
+// commitStatusState normalizes the state of a commit status.
+func commitStatusState(state string) string {
+	switch strings.ToLower(state) {
+	case "success":
+		return CheckSuccess
+	case "pending", "expected":
+		return CheckPending
+	default:
+		return CheckFailure
+	}
+}
+
+// RequiredChecks returns the checks that must pass before the PR can be merged.
+func RequiredChecks(checks []Check) []Check {
+	var required []Check
+	for _, c := range checks {
+		if c.Required {
+			required = append(required, c)
+		}
+	}
+	return required
+}
+
+// PullRequestChecks returns the checks of the head commit of the PR, marking the ones required by the
+// protection of its base branch. Required checks that have not been reported yet are included as pending.
+// Reference: https://docs.github.com/en/graphql/reference/objects#statuscheckrollup
+func (c *Client) PullRequestChecks(repo string, pr *PullRequestResult) ([]Check, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo: %s", repo)
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+		repository(owner: $owner, name: $name) { pullRequest(number: $number) { commits(last: 1) { nodes { commit {
+			statusCheckRollup { contexts(first: 100, after: $after) {
+				pageInfo { hasNextPage endCursor }
+				nodes {
+					... on CheckRun { name status conclusion detailsUrl startedAt completedAt isRequired(pullRequestNumber: $number) }
+					... on StatusContext { context state targetUrl createdAt isRequired(pullRequestNumber: $number) }
+				}
+			} }
+		} } } } }
+	}`
+
+	var checks []Check
+	reported := make(map[string]bool)
+	variables := map[string]interface{}{"owner": parts[0], "name": parts[1], "number": pr.Number, "after": nil}
+	for {
+		var data rollupResponse
+		if err := c.graphQL(query, variables, &data); err != nil {
+			return nil, err
+		}
+		commits := data.Repository.PullRequest.Commits.Nodes
+		if len(commits) == 0 || commits[0].Commit.StatusCheckRollup == nil {
+			break
+		}
+		contexts := commits[0].Commit.StatusCheckRollup.Contexts
+		for _, n := range contexts.Nodes {
+			check := n.check()
+			reported[check.Name] = true
+			checks = append(checks, check)
+		}
+		if !contexts.PageInfo.HasNextPage {
+			break
+		}
+		variables["after"] = contexts.PageInfo.EndCursor
+	}
+
+	contexts, err := c.requiredContexts(repo, pr.Base.Ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range contexts {
+		if !reported[name] {
+			checks = append(checks, Check{Name: name, State: CheckPending, Required: true})
+		}
+	}
+	return checks, nil
+}
+
+// rollupResponse is the data structure for the status check rollup from GitHub's GraphQL API response.
+type rollupResponse struct {
+	Repository struct {
+		PullRequest struct {
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts struct {
+								PageInfo pageInfo     `json:"pageInfo"`
+								Nodes    []rollupNode `json:"nodes"`
+							} `json:"contexts"`
+						} `json:"statusCheckRollup"`
+					} `json:"commit"`
+				} `json:"nodes"`
+			} `json:"commits"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// pageInfo is the pagination data of a GraphQL connection.
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// rollupNode is a check run or a commit status in the status check rollup.
+type rollupNode struct {
+	// Check run fields.
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	DetailsURL  string     `json:"detailsUrl"`
+	StartedAt   *time.Time `json:"startedAt"`
+	CompletedAt *time.Time `json:"completedAt"`
+	// Commit status fields.
+	Context   string     `json:"context"`
+	State     string     `json:"state"`
+	TargetURL string     `json:"targetUrl"`
+	CreatedAt *time.Time `json:"createdAt"`
+
+	IsRequired bool `json:"isRequired"`
+}
+
+// check normalizes the check run or commit status.
+func (n rollupNode) check() Check {
+	if n.Context != "" {
+		return Check{
+			Name:      n.Context,
+			State:     commitStatusState(n.State),
+			URL:       n.TargetURL,
+			StartedAt: n.CreatedAt,
+			Required:  n.IsRequired,
+		}
+	}
+	return Check{
+		Name:        n.Name,
+		State:       checkRunState(strings.ToLower(n.Status), strings.ToLower(n.Conclusion)),
+		URL:         n.DetailsURL,
+		StartedAt:   n.StartedAt,
+		CompletedAt: n.CompletedAt,
+		Required:    n.IsRequired,
+	}
+}
+
+// requiredContexts returns the names of the status checks required by the protection of the branch.
+// Returns nil if the branch is not protected or the protection cannot be read with the credentials.
+// Reference: https://docs.github.com/en/rest/reference/repos#get-status-checks-protection
+func (c *Client) requiredContexts(repo, branch string) ([]string, error) {
+	var protection struct {
+		Contexts []string `json:"contexts"`
+		Checks   []struct {
+			Context string `json:"context"`
+		} `json:"checks"`
+	}
+	err := c.do("GET", fmt.Sprintf("repos/%s/branches/%s/protection/required_status_checks", repo, url.PathEscape(branch)), nil, &protection)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusForbidden) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := protection.Contexts
+	for _, check := range protection.Checks {
+		if !contains(contexts, check.Context) {
+			contexts = append(contexts, check.Context)
+		}
+	}
+	return contexts, nil
+}
+
+// contains returns true if the list contains the value.
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestChecksState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []string
+		want   string
+	}{
+		{"no checks", nil, CheckNone},
+		{"all passed", []string{CheckSuccess, CheckSuccess}, CheckSuccess},
+		{"running", []string{CheckSuccess, CheckPending}, CheckPending},
+		{"failed", []string{CheckPending, CheckFailure, CheckSuccess}, CheckFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var checks []Check
+			for _, s := range tt.states {
+				checks = append(checks, Check{State: s})
+			}
+			if got := ChecksState(checks); got != tt.want {
+				t.Errorf("ChecksState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPullRequestChecks(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			w.Write([]byte(`{"data": {"repository": {"pullRequest": {"commits": {"nodes": [{"commit": {"statusCheckRollup": {"contexts": {
+				"pageInfo": {"hasNextPage": false, "endCursor": "x"},
+				"nodes": [
+					{"name": "build", "status": "COMPLETED", "conclusion": "SUCCESS", "isRequired": true},
+					{"name": "lint", "status": "IN_PROGRESS", "isRequired": false},
+					{"context": "ci/coverage", "state": "FAILURE", "isRequired": false}
+				]}}}}]}}}}}`))
+		case "/repos/o/r/branches/develop/protection/required_status_checks":
+			w.Write([]byte(`{"contexts": ["build"], "checks": [{"context": "build"}, {"context": "deploy-preview"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	pr := &PullRequestResult{Number: 7}
+	pr.Base.Ref = "develop"
+	checks, err := c.PullRequestChecks("o/r", pr)
+	if err != nil {
+		t.Fatalf("PullRequestChecks() error = %v", err)
+	}
+
+	var got []string
+	for _, check := range RequiredChecks(checks) {
+		got = append(got, check.Name+":"+check.State)
+	}
+	want := []string{"build:" + CheckSuccess, "deploy-preview:" + CheckPending}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RequiredChecks() = %v, want %v", got, want)
+	}
+	if len(checks) != 4 {
+		t.Errorf("PullRequestChecks() returned %d checks, want 4", len(checks))
+	}
+}
+
+func TestPullRequestChecksUnprotectedBranch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			w.Write([]byte(`{"data": {"repository": {"pullRequest": {"commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Branch not protected"}`))
+		}
+	})
+
+	pr := &PullRequestResult{Number: 7}
+	pr.Base.Ref = "develop"
+	checks, err := c.PullRequestChecks("o/r", pr)
+	if err != nil || len(checks) != 0 {
+		t.Errorf("PullRequestChecks() = %v, %v, want no checks", checks, err)
+	}
+}
+
+func TestPullRequestChecksBranchWithSlash(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/graphql":
+			w.Write([]byte(`{"data": {"repository": {"pullRequest": {"commits": {"nodes": [{"commit": {"statusCheckRollup": null}}]}}}}}`))
+		case "/repos/o/r/branches/release%2F1.0/protection/required_status_checks":
+			w.Write([]byte(`{"contexts": ["build"]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	pr := &PullRequestResult{Number: 7}
+	pr.Base.Ref = "release/1.0"
+	checks, err := c.PullRequestChecks("o/r", pr)
+	if err != nil {
+		t.Fatalf("PullRequestChecks() error = %v", err)
+	}
+	if state := ChecksState(RequiredChecks(checks)); state != CheckPending {
+		t.Errorf("required checks state = %s, want %s", state, CheckPending)
+	}
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/greganswer/workflow/git"
+)
+
+// cliTransport sends HTTP requests through the "gh api" command so the
+// GitHub credentials of the "gh" CLI app can be used instead of a token.
+// The command is run with git.DefaultRunner and stopped when the request context is done,
+// so the timeout of the HTTP client applies.
+// Reference: https://cli.github.com/manual/gh_api
+type cliTransport struct {
+	// host is the GitHub host name. Example: github.corp.example
+	host string
+	// pathPrefix of the API URL which "gh api" adds itself. Example: /api/v3
+	pathPrefix string
+}
+
+// RoundTrip executes the request with "gh api" and parses the included HTTP response.
+func (t *cliTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := strings.TrimPrefix(req.URL.RequestURI(), t.pathPrefix)
+	endpoint = strings.TrimPrefix(endpoint, "/")
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		endpoint = "graphql"
+	}
+	args := []string{"api", endpoint, "--include", "--method", req.Method}
+	if t.host != "" {
+		args = append(args, "--hostname", t.host)
+	}
+	for key := range req.Header {
+		if key == "Authorization" {
+			continue
+		}
+		args = append(args, "--header", fmt.Sprintf("%s: %s", key, req.Header.Get(key)))
+	}
+
+	var stdin io.Reader
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(body) > 0 {
+			args = append(args, "--input", "-")
+			stdin = bytes.NewReader(body)
+		}
+	}
+
+	out, err := git.DefaultRunner.Run(req.Context(), stdin, "gh", args...)
+
+	// "gh api" exits with an error for HTTP error statuses but still prints the response.
+	res, parseErr := parseCLIResponse(out, req)
+	if parseErr != nil {
+		if err != nil {
+			return nil, err
+		}
+		return nil, parseErr
+	}
+	return res, nil
+}
+
+// parseCLIResponse parses the status line, headers and body printed by "gh api --include".
+func parseCLIResponse(out []byte, req *http.Request) (*http.Response, error) {
+	r := bufio.NewReader(bytes.NewReader(out))
+
+	statusLine, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("malformed gh api response: %q", out)
+	}
+	parts := strings.SplitN(strings.TrimSpace(statusLine), " ", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "HTTP/") {
+		return nil, fmt.Errorf("malformed gh api status line: %q", statusLine)
+	}
+	code, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed gh api status code: %q", statusLine)
+	}
+
+	res := &http.Response{
+		Status:     strings.Join(parts[1:], " "),
+		StatusCode: code,
+		Proto:      parts[0],
+		Header:     make(http.Header),
+		Request:    req,
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" || err != nil {
+			break
+		}
+		if kv := strings.SplitN(line, ":", 2); len(kv) == 2 {
+			res.Header.Add(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+		}
+	}
+
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	res.ContentLength = int64(len(body))
+	return res, nil
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultAPIURL is the base URL of the GitHub REST API.
+const DefaultAPIURL = "https://api.github.com"
+
+const requestTimeout = 30 * time.Second
+
+// ErrPullRequestExists is returned when a PR already exists for the branch.
+var ErrPullRequestExists = errors.New("a pull request already exists for this branch")
+
+// ErrPullRequestNotFound is returned when no PR exists for the branch.
+var ErrPullRequestNotFound = errors.New("no pull request found for this branch")
+
+// Config contains GitHub configuration values.
+type Config struct {
+	Username string
+	Token    string
+	Host     string
+	APIURL   string
+}
+
+// Client makes requests to the GitHub REST API.
+// Requests go through the "gh" CLI app when no token is configured.
+type Client struct {
+	BaseURL    string
+	Token      string
+	httpClient *http.Client
+}
+
+// APIError is the data structure for an error response from GitHub's JSON API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"message"`
+	Errors     []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Error message from the API.
+func (e *APIError) Error() string {
+	msg := e.Message
+	for _, detail := range e.Errors {
+		if detail.Message != "" {
+			msg += ": " + detail.Message
+		}
+	}
+	return fmt.Sprintf("GitHub API request failed with %d HTTP status: %s", e.StatusCode, msg)
+}
+
+// NewClient creates a GitHub API client from the config.
+func NewClient(c *Config) *Client {
+	baseURL := c.APIURL
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+
+	httpClient := &http.Client{Timeout: requestTimeout}
+	if c.Token == "" {
+		httpClient.Transport = &cliTransport{host: c.Host, pathPrefix: apiPathPrefix(baseURL)}
+	}
+
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Token:      c.Token,
+		httpClient: httpClient,
+	}
+}
+
+// do makes a request to the API path and decodes the JSON response into out.
+// A nil reqBody sends no body and a nil out discards the response.
+func (c *Client) do(method, path string, reqBody interface{}, out interface{}) error {
+	return c.doURL(method, c.BaseURL+"/"+strings.TrimPrefix(path, "/"), reqBody, out)
+}
+
+// graphQLURL returns the URL of the GraphQL API. Example: https://github.corp.example/api/graphql
+func (c *Client) graphQLURL() string {
+	if strings.HasSuffix(c.BaseURL, "/api/v3") {
+		return strings.TrimSuffix(c.BaseURL, "/v3") + "/graphql"
+	}
+	return c.BaseURL + "/graphql"
+}
+
+// graphQL makes a request to the GraphQL API and decodes the "data" of the JSON response into out.
+// Reference: https://docs.github.com/en/graphql/guides/forming-calls-with-graphql
+func (c *Client) graphQL(query string, variables map[string]interface{}, out interface{}) error {
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	reqBody := map[string]interface{}{"query": query, "variables": variables}
+	if err := c.doURL("POST", c.graphQLURL(), reqBody, &res); err != nil {
+		return err
+	}
+	if len(res.Errors) > 0 {
+		return fmt.Errorf("GitHub GraphQL request failed: %s", res.Errors[0].Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(res.Data, out), "decode failed")
+}
+
+// doURL makes a request to the URL and decodes the JSON response into out.
+func (c *Client) doURL(method, URL string, reqBody interface{}, out interface{}) error {
+	resBody, err := c.request(method, URL, reqBody)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(resBody) == 0 {
+		return nil
+	}
+	return errors.Wrap(json.Unmarshal(resBody, out), "decode failed")
+}
+
+// getText makes a GET request to the API path and returns the plain text response.
+func (c *Client) getText(path string) (string, error) {
+	resBody, err := c.request("GET", c.BaseURL+"/"+strings.TrimPrefix(path, "/"), nil)
+	return string(resBody), err
+}
+
+// getPages makes GET requests to the API path and each following page from the Link header,
+// passing each response body to decode.
+// Reference: https://docs.github.com/en/rest/guides/traversing-with-pagination
+func (c *Client) getPages(path string, decode func(page []byte) error) error {
+	URL := c.BaseURL + "/" + strings.TrimPrefix(path, "/")
+	for URL != "" {
+		page, header, err := c.requestWithHeader("GET", URL, nil)
+		if err != nil {
+			return err
+		}
+		if err := decode(page); err != nil {
+			return errors.Wrap(err, "decode failed")
+		}
+		URL = nextPageURL(header.Get("Link"))
+	}
+	return nil
+}
+
+// nextPageURL returns the URL of the next page from the Link header, or an empty string on the last page.
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		sections := strings.Split(part, ";")
+		if len(sections) < 2 {
+			continue
+		}
+		for _, param := range sections[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(sections[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// request makes a request to the URL and returns the response body.
+// A nil reqBody sends no body.
+func (c *Client) request(method, URL string, reqBody interface{}) ([]byte, error) {
+	body, _, err := c.requestWithHeader(method, URL, reqBody)
+	return body, err
+}
+
+// requestWithHeader makes a request to the URL and returns the response body and header.
+// A nil reqBody sends no body.
+func (c *Client) requestWithHeader(method, URL string, reqBody interface{}) ([]byte, http.Header, error) {
+	var body []byte
+	if reqBody != nil {
+		var err error
+		if body, err = json.Marshal(reqBody); err != nil {
+			return nil, nil, errors.Wrap(err, "JSON marshal failed")
+		}
+	}
+
+	req, err := http.NewRequest(method, URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "request failed")
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "request failed")
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read failed")
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		e := &APIError{StatusCode: res.StatusCode}
+		if err := json.Unmarshal(resBody, e); err != nil {
+			e.Message = string(resBody)
+		}
+		return nil, nil, e
+	}
+	return resBody, res.Header, nil
+}
@@ -0,0 +1,262 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/greganswer/workflow/git"
+)
+
+// newTestClient returns a client for a test server that serves the handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(&Config{Token: "secret", APIURL: server.URL})
+}
+
+// decodeBody decodes the JSON request body into out.
+func decodeBody(t *testing.T, r *http.Request, out interface{}) {
+	t.Helper()
+	if err := json.NewDecoder(r.Body).Decode(out); err != nil {
+		t.Fatalf("decode request body: %v", err)
+	}
+}
+
+func TestCreatePullRequest(t *testing.T) {
+	var requests []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if got := r.Header.Get("Authorization"); got != "token secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.URL.Path {
+		case "/repos/o/r/pulls":
+			var body map[string]interface{}
+			decodeBody(t, r, &body)
+			if body["title"] != "ABC-1: Title" || body["head"] != "feature-abc-1-title" || body["draft"] != true {
+				t.Errorf("create body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"number": 7, "html_url": "https://github.com/o/r/pull/7", "state": "open", "draft": true}`))
+		case "/repos/o/r/pulls/7/requested_reviewers":
+			var body map[string][]string
+			decodeBody(t, r, &body)
+			if !reflect.DeepEqual(body["reviewers"], []string{"alice"}) {
+				t.Errorf("reviewers = %v", body["reviewers"])
+			}
+			w.WriteHeader(http.StatusCreated)
+		case "/repos/o/r/issues/7":
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	pr := PullRequest{
+		Title:     "ABC-1: Title",
+		Head:      "feature-abc-1-title",
+		Base:      "develop",
+		Reviewers: []string{"alice"},
+		Assignees: []string{"bob"},
+		Draft:     true,
+	}
+	result, err := c.CreatePullRequest("o/r", pr)
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if result.Number != 7 || result.URL != "https://github.com/o/r/pull/7" || !result.Draft {
+		t.Errorf("CreatePullRequest() = %+v", result)
+	}
+	want := []string{"POST /repos/o/r/pulls", "POST /repos/o/r/pulls/7/requested_reviewers", "PATCH /repos/o/r/issues/7"}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+}
+
+func TestCreatePullRequestAlreadyExists(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed", "errors": [{"message": "A pull request already exists for o:feature-abc-1-title."}]}`))
+	})
+
+	_, err := c.CreatePullRequest("o/r", PullRequest{Head: "feature-abc-1-title", Base: "develop"})
+	if err != ErrPullRequestExists {
+		t.Errorf("CreatePullRequest() error = %v, want %v", err, ErrPullRequestExists)
+	}
+}
+
+func TestCreatePullRequestValidationFailed(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed", "errors": [{"message": "No commits between develop and feature"}]}`))
+	})
+
+	_, err := c.CreatePullRequest("o/r", PullRequest{Head: "feature", Base: "develop"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("CreatePullRequest() error = %v, want an APIError", err)
+	}
+}
+
+func TestFindPullRequest(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/o/r/pulls" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		switch r.URL.Query().Get("head") {
+		case "o:feature-abc-1-title":
+			if state := r.URL.Query().Get("state"); state != "all" {
+				t.Errorf("state = %q, want all", state)
+			}
+			w.Write([]byte(`[{"number": 3, "state": "closed", "merged_at": "2021-01-02T03:04:05Z", "head": {"ref": "feature-abc-1-title"}, "base": {"ref": "develop"}}]`))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	})
+
+	pr, err := c.FindPullRequest("o/r", "feature-abc-1-title")
+	if err != nil {
+		t.Fatalf("FindPullRequest() error = %v", err)
+	}
+	if pr.Number != 3 || !pr.IsMerged() || pr.Base.Ref != "develop" {
+		t.Errorf("FindPullRequest() = %+v", pr)
+	}
+
+	if _, err := c.FindPullRequest("o/r", "missing"); err != ErrPullRequestNotFound {
+		t.Errorf("FindPullRequest(missing) error = %v, want %v", err, ErrPullRequestNotFound)
+	}
+}
+
+func TestRequestReviewers(t *testing.T) {
+	called := false
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Method != "POST" || r.URL.Path != "/repos/o/r/pulls/7/requested_reviewers" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string][]string
+		decodeBody(t, r, &body)
+		want := map[string][]string{"reviewers": {"alice"}, "team_reviewers": {"backend", "web"}}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	if err := c.RequestReviewers("o/r", 7, []string{"alice"}, []string{"org/backend", "web"}); err != nil {
+		t.Fatalf("RequestReviewers() error = %v", err)
+	}
+	if !called {
+		t.Error("RequestReviewers() made no request")
+	}
+}
+
+func TestRequestReviewersNone(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	if err := c.RequestReviewers("o/r", 7, nil, nil); err != nil {
+		t.Errorf("RequestReviewers() error = %v", err)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "message",
+			body: `{"message": "Not Found"}`,
+			want: "GitHub API request failed with 404 HTTP status: Not Found",
+		},
+		{
+			name: "message with details",
+			body: `{"message": "Validation Failed", "errors": [{"message": "first"}, {"resource": "Issue"}, {"message": "second"}]}`,
+			want: "GitHub API request failed with 404 HTTP status: Validation Failed: first: second",
+		},
+		{
+			name: "plain text body",
+			body: "upstream unavailable",
+			want: "GitHub API request failed with 404 HTTP status: upstream unavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(tt.body))
+			})
+			_, err := c.FindPullRequest("o/r", "branch")
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCLIResponse(t *testing.T) {
+	out := "HTTP/2.0 404 Not Found\r\nContent-Type: application/json\r\nLink: <https://api.github.com/next>; rel=\"next\"\r\n\r\n{\"message\": \"Not Found\"}"
+	res, err := parseCLIResponse([]byte(out), nil)
+	if err != nil {
+		t.Fatalf("parseCLIResponse() error = %v", err)
+	}
+	if res.StatusCode != http.StatusNotFound || res.Status != "404 Not Found" {
+		t.Errorf("status = %d %q", res.StatusCode, res.Status)
+	}
+	if got := res.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	if string(body) != `{"message": "Not Found"}` {
+		t.Errorf("body = %q", body)
+	}
+
+	if _, err := parseCLIResponse([]byte("gh: command not found"), nil); err == nil {
+		t.Error("parseCLIResponse(malformed) error = nil, want an error")
+	}
+}
+
+func TestCLITransportUsesRequestContext(t *testing.T) {
+	git.UseFakeRunner(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", DefaultAPIURL+"/repos/o/r/pulls", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (&cliTransport{}).RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCLITransport(t *testing.T) {
+	fake := git.UseFakeRunner(t)
+	fake.Script("gh api repos/o/r/pulls/7 --include --method GET --hostname github.corp.example --header Accept: application/json",
+		"HTTP/2.0 200 OK\r\nContent-Type: application/json\r\n\r\n{\"number\": 7}", nil)
+	c := NewClient(&Config{Host: "github.corp.example", APIURL: "https://github.corp.example/api/v3"})
+
+	req, err := http.NewRequest("GET", c.BaseURL+"/repos/o/r/pulls/7", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer res.Body.Close()
+	var pr PullRequestResult
+	if err := json.NewDecoder(res.Body).Decode(&pr); err != nil || pr.Number != 7 {
+		t.Errorf("response = %+v, %v", pr, err)
+	}
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// Config keys for the PR title and body templates.
+// The *_file keys contain paths relative to the project root.
+const (
+	TitleFormatConfigKey     = "github.pr.title_template"
+	TitleFormatFileConfigKey = "github.pr.title_template_file"
+	BodyFormatConfigKey      = "github.pr.body_template"
+	BodyFormatFileConfigKey  = "github.pr.body_template_file"
+)
+
+// Default PR title and body templates.
+// Only the part of the body between the generated section markers is updated when a PR is updated.
+const (
+	DefaultTitleFormat = "{{.Issue}}"
+	DefaultBodyFormat  = GeneratedStartMarker + "\n## [Issue #{{.Issue.ID}}]({{.Issue.WebURL}})\n" + GeneratedEndMarker + "\n\n{{.Template}}"
+)
+
+// formatFuncs are the functions available to the PR title and body templates.
+var formatFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// PrData is the data available to the PR title and body templates.
+type PrData struct {
+	Issue  issues.Issue
+	Branch string
+	Base   string
+	// Commits are the subjects of the commits on the branch.
+	Commits []string
+	// Files are the paths of the files changed on the branch.
+	Files []string
+	// Template is the contents of the PR template file.
+	Template string
+}
+
+// PrFormat contains the Go text/template sources for the PR title and body.
+// Reference: https://golang.org/pkg/text/template
+type PrFormat struct {
+	Title string
+	Body  string
+}
+
+// samplePrData is representative data for validating templates.
+// Every field is set so templates may index or range over them like with real data.
+var samplePrData = PrData{
+	Issue: issues.Issue{
+		ID:       "ABC-123",
+		Title:    "Add the sample feature",
+		Type:     issues.StoryCategory,
+		Status:   "In Progress",
+		APIURL:   "https://example.atlassian.net/rest/api/2/issue/ABC-123",
+		WebURL:   "https://example.atlassian.net/browse/ABC-123",
+		Assignee: "Sample User",
+		Labels:   []string{"backend", "frontend"},
+	},
+	Branch:   "feature-abc-123-add-the-sample-feature",
+	Base:     "master",
+	Commits:  []string{"ABC-123: Add the sample feature", "ABC-123: Add tests", "ABC-123: Update docs"},
+	Files:    []string{"README.md", "main.go", "main_test.go"},
+	Template: "## Description\n",
+}
+
+// Validate parses the title and body templates and renders them with sample data
+// to catch references to unknown fields.
+func (f PrFormat) Validate() error {
+	_, _, err := f.Render(samplePrData)
+	return err
+}
+
+// parse the title and body templates, using the defaults for empty ones.
+func (f PrFormat) parse() (*template.Template, error) {
+	title, body := f.Title, f.Body
+	if strings.TrimSpace(title) == "" {
+		title = DefaultTitleFormat
+	}
+	if strings.TrimSpace(body) == "" {
+		body = DefaultBodyFormat
+	}
+
+	t, err := template.New("title").Funcs(formatFuncs).Option("missingkey=error").Parse(title)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pull request title template")
+	}
+	if _, err = t.New("body").Parse(body); err != nil {
+		return nil, errors.Wrap(err, "invalid pull request body template")
+	}
+	return t, nil
+}
+
+// Render the PR title and body with the data.
+func (f PrFormat) Render(data PrData) (title, body string, err error) {
+	t, err := f.parse()
+	if err != nil {
+		return "", "", err
+	}
+
+	var b bytes.Buffer
+	if err = t.ExecuteTemplate(&b, "title", data); err != nil {
+		return "", "", errors.Wrap(err, "render pull request title failed")
+	}
+	// Titles are a single line.
+	title = strings.Join(strings.Fields(b.String()), " ")
+
+	b.Reset()
+	if err = t.ExecuteTemplate(&b, "body", data); err != nil {
+		return "", "", errors.Wrap(err, "render pull request body failed")
+	}
+	return title, b.String(), nil
+}
@@ -0,0 +1,26 @@
+package github
+
+import "testing"
+
+func TestPrFormatValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  PrFormat
+		wantErr bool
+	}{
+		{"defaults", PrFormat{}, false},
+		{"first commit", PrFormat{Title: "{{index .Commits 0}}"}, false},
+		{"issue fields", PrFormat{Title: "{{.Issue.ID}} {{.Issue.Title}}", Body: "{{index .Issue.Labels 0}}"}, false},
+		{"files", PrFormat{Body: "{{range .Files}}- {{.}}\n{{end}}{{join .Commits \", \"}}"}, false},
+		{"syntax error", PrFormat{Title: "{{.Issue.Title"}, true},
+		{"unknown field", PrFormat{Body: "{{.Ticket}}"}, true},
+		{"unknown function", PrFormat{Title: "{{title .Branch}}"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.format.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,73 +4,68 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os/exec"
-	"path"
-
-	"github.com/greganswer/workflow/file"
-	"github.com/greganswer/workflow/git"
-	"github.com/greganswer/workflow/issues"
 )
 
 const CLIInstallationInstructions = "https://cli.github.com"
-const UsernameConfigKey = "github.username"
 
-var pRBodyTemplatePath = path.Join(git.RootDir(), ".github", "PULL_REQUEST_TEMPLATE.md")
+// Config keys.
+const (
+	UsernameConfigKey = "github.username"
+	TokenConfigKey    = "github.token"
+)
 
 // PullRequest contains GitHub Pull Request data.
 type PullRequest struct {
-	Reviewers string
-	Base      string
-	Body      string
-	Title     string
-	Template  string
-	Draft     bool
+	Reviewers     []string
+	TeamReviewers []string
+	Assignees     []string
+	Labels        []string
+	Milestone     string
+	Head          string
+	Base          string
+	Body          string
+	Title         string
+	Template      string
+	Draft         bool
 }
 
 // NewPr create the Pull Request data structure ready to create a PR on GitHub.
-func NewPr(issue issues.Issue, baseBranch, reviewers string, draft bool) (PullRequest, error) {
+// The title and body are rendered from the format, including the template file unless its path is empty.
+// The reviewers are a comma separated list of users and "org/team" teams.
+func NewPr(data PrData, format PrFormat, reviewers, templatePath string, draft bool) (PullRequest, error) {
 	template := "None"
-	body := fmt.Sprintf("## [Issue #%s](%s)\n\n", issue.ID, issue.WebURL)
-
-	exists, err := file.Exists(pRBodyTemplatePath)
-	if exists {
-		b, err := ioutil.ReadFile(pRBodyTemplatePath)
-		if err == nil {
-			template = pRBodyTemplatePath
-			body += string(b)
+	if templatePath != "" {
+		b, err := ioutil.ReadFile(templatePath)
+		if err != nil {
+			return PullRequest{}, err
 		}
+		template = templatePath
+		data.Template = string(b)
 	}
 
+	title, body, err := format.Render(data)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	users, teams := SplitReviewers(reviewers)
+
 	return PullRequest{
-		Title:     issue.String(),
-		Base:      baseBranch,
-		Template:  template,
-		Body:      body,
-		Reviewers: reviewers,
-		Draft:     draft,
-	}, err
+		Title:         title,
+		Head:          data.Branch,
+		Base:          data.Base,
+		Template:      template,
+		Body:          body,
+		Reviewers:     users,
+		TeamReviewers: teams,
+		Draft:         draft,
+	}, nil
 }
 
-// Create a Pull Request on GitHub.
-// Reference: https://cli.github.com/manual/gh_pr_create
-func (p *PullRequest) Create() error {
+// Create a Pull Request on GitHub in the repo ("owner/name").
+func (p *PullRequest) Create(c *Client, repo string) (*PullRequestResult, error) {
 	fmt.Println("Creating Pull Request on GitHub...")
-
-	if p.Draft {
-		return executeAndStream("gh", "pr", "create",
-			"--base", p.Base,
-			"--title", p.Title,
-			"--body", p.Body,
-			"--reviewer", p.Reviewers,
-			"--draft",
-		)
-	}
-
-	return executeAndStream("gh", "pr", "create",
-		"--base", p.Base,
-		"--title", p.Title,
-		"--body", p.Body,
-		"--reviewer", p.Reviewers,
-	)
+	return c.CreatePullRequest(repo, *p)
 }
 
 // CLIExists returns true if the "gh" app exists.
@@ -78,9 +73,3 @@ func CLIExists() bool {
 	_, err := exec.LookPath("gh")
 	return err == nil
 }
-
-// OpenPR opens the PR for the given branch.
-// Reference: https://cli.github.com/manual/gh_pr_view
-func OpenPR(branch string) error {
-	return executeAndStream("gh", "pr", "view", branch, "--web")
-}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultHost is the host of public GitHub.
+const DefaultHost = "github.com"
+
+// HostsConfigKey is the config key for the list of GitHub hosts.
+const HostsConfigKey = "github.hosts"
+
+// Host contains the configuration of a GitHub host, such as a GitHub Enterprise Server.
+//
+// Example config:
+//
+//	github:
+//	  hosts:
+//	    - host: github.corp.example
+//	      api_url: https://github.corp.example/api/v3
+//	      token: <token>
+type Host struct {
+	Name   string `mapstructure:"host"`
+	APIURL string `mapstructure:"api_url"`
+	Token  string `mapstructure:"token"`
+}
+
+// FindHost returns the configuration for the host name.
+// Hosts that are not configured use the default GitHub or GitHub Enterprise Server API URL.
+func FindHost(name string, hosts []Host) Host {
+	if name == "" {
+		name = DefaultHost
+	}
+	for _, h := range hosts {
+		if strings.EqualFold(h.Name, name) {
+			if h.APIURL == "" {
+				h.APIURL = defaultAPIURL(name)
+			}
+			return h
+		}
+	}
+	return Host{Name: name, APIURL: defaultAPIURL(name)}
+}
+
+// defaultAPIURL of the host.
+// Reference: https://docs.github.com/en/enterprise-server/rest/overview/resources-in-the-rest-api
+func defaultAPIURL(host string) string {
+	if strings.EqualFold(host, DefaultHost) {
+		return DefaultAPIURL
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+// TokenEnvVar returns the environment variable holding the token for the host.
+// These match the variables used by the "gh" CLI app.
+func TokenEnvVar(host string) string {
+	if host == "" || strings.EqualFold(host, DefaultHost) {
+		return "GITHUB_TOKEN"
+	}
+	return "GITHUB_ENTERPRISE_TOKEN"
+}
+
+// apiPathPrefix returns the path of the API URL which "gh api" adds itself. Example: /api/v3
+func apiPathPrefix(apiURL string) string {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
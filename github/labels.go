@@ -0,0 +1,39 @@
+package github
+
+import (
+	"strings"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// LabelsConfigKey is the config key for the table mapping Jira issue types and labels to GitHub labels.
+//
+// Example config:
+//
+//	github:
+//	  labels:
+//	    Bug: bug
+//	    Story: enhancement
+//	    tech-debt: technical debt
+const LabelsConfigKey = "github.labels"
+
+// LabelsForIssue returns the GitHub labels mapped from the issue type and labels.
+// The table keys are case insensitive.
+func LabelsForIssue(issue issues.Issue, table map[string]string) []string {
+	lookup := make(map[string]string, len(table))
+	for key, label := range table {
+		lookup[strings.ToLower(key)] = label
+	}
+
+	var labels []string
+	seen := make(map[string]bool)
+	for _, key := range append([]string{issue.Type}, issue.Labels...) {
+		label, ok := lookup[strings.ToLower(key)]
+		if !ok || label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	return labels
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"errors"
+	"strings"
+)
+
+// Markers around the generated section of a PR body.
+// Text outside of the markers is never changed when a PR is updated.
+const (
+	GeneratedStartMarker = "<!-- workflow:start -->"
+	GeneratedEndMarker   = "<!-- workflow:end -->"
+)
+
+// ErrNoGeneratedSection is returned when a PR body has no generated section markers.
+var ErrNoGeneratedSection = errors.New("pull request body has no " + GeneratedStartMarker + " and " + GeneratedEndMarker + " markers")
+
+// generatedSection returns the start and end index of the generated section, including the markers.
+func generatedSection(body string) (start, end int, ok bool) {
+	start = strings.Index(body, GeneratedStartMarker)
+	if start < 0 {
+		return 0, 0, false
+	}
+	end = strings.Index(body[start:], GeneratedEndMarker)
+	if end < 0 {
+		return 0, 0, false
+	}
+	return start, start + end + len(GeneratedEndMarker), true
+}
+
+// ReplaceGeneratedSection replaces the generated section of the current body with the one in the generated body.
+// A generated body without markers is used as a whole.
+// Returns ErrNoGeneratedSection if the current body has no markers.
+func ReplaceGeneratedSection(current, generated string) (string, error) {
+	start, end, ok := generatedSection(current)
+	if !ok {
+		return current, ErrNoGeneratedSection
+	}
+	return current[:start] + markedSection(generated) + current[end:], nil
+}
+
+// InsertGeneratedSection adds the generated section of the generated body to the top of the current body,
+// so later updates can replace it. A generated body without markers is used as a whole.
+func InsertGeneratedSection(current, generated string) string {
+	if strings.TrimSpace(current) == "" {
+		return markedSection(generated) + "\n"
+	}
+	return markedSection(generated) + "\n\n" + current
+}
+
+// markedSection returns the generated section of the body, including the markers.
+// A body without markers is wrapped in them.
+func markedSection(body string) string {
+	if start, end, ok := generatedSection(body); ok {
+		return body[start:end]
+	}
+	return GeneratedStartMarker + "\n" + strings.TrimSpace(body) + "\n" + GeneratedEndMarker
+}
@@ -0,0 +1,72 @@
+package github
+
+import "testing"
+
+const (
+	markerStart = GeneratedStartMarker
+	markerEnd   = GeneratedEndMarker
+)
+
+func TestReplaceGeneratedSection(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		generated string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "keeps text outside the markers",
+			current:   "Intro\n" + markerStart + "\nold\n" + markerEnd + "\nNotes",
+			generated: markerStart + "\nnew\n" + markerEnd + "\n\nTemplate",
+			want:      "Intro\n" + markerStart + "\nnew\n" + markerEnd + "\nNotes",
+		},
+		{
+			name:      "generated body without markers",
+			current:   markerStart + "\nold\n" + markerEnd + "\nNotes",
+			generated: "new\n",
+			want:      markerStart + "\nnew\n" + markerEnd + "\nNotes",
+		},
+		{
+			name:      "current body without markers",
+			current:   "Written by hand",
+			generated: markerStart + "\nnew\n" + markerEnd,
+			want:      "Written by hand",
+			wantErr:   ErrNoGeneratedSection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReplaceGeneratedSection(tt.current, tt.generated)
+			if err != tt.wantErr {
+				t.Fatalf("ReplaceGeneratedSection() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReplaceGeneratedSection() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertGeneratedSection(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		generated string
+		want      string
+	}{
+		{"marked section", "Written by hand", markerStart + "\nnew\n" + markerEnd + "\n\nTemplate", markerStart + "\nnew\n" + markerEnd + "\n\nWritten by hand"},
+		{"unmarked body", "Written by hand", "new", markerStart + "\nnew\n" + markerEnd + "\n\nWritten by hand"},
+		{"empty body", "", "new", markerStart + "\nnew\n" + markerEnd + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InsertGeneratedSection(tt.current, tt.generated); got != tt.want {
+				t.Errorf("InsertGeneratedSection() = %q, want %q", got, tt.want)
+			}
+			if _, err := ReplaceGeneratedSection(InsertGeneratedSection(tt.current, tt.generated), "newer"); err != nil {
+				t.Errorf("ReplaceGeneratedSection() after insert error = %v", err)
+			}
+		})
+	}
+}
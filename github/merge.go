@@ -0,0 +1,83 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeMethodConfigKey is the config key for the default merge method.
+const MergeMethodConfigKey = "github.merge_method"
+
+// Merge methods.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// Review decisions.
+// Reference: https://docs.github.com/en/graphql/reference/enums#pullrequestreviewdecision
+const (
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+	ReviewRequired         = "REVIEW_REQUIRED"
+)
+
+// ValidateMergeMethod returns an error if the method is not merge, squash or rebase.
+func ValidateMergeMethod(method string) error {
+	switch method {
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+		return nil
+	}
+	return fmt.Errorf("invalid merge method %q. expected %s, %s or %s", method, MergeMethodMerge, MergeMethodSquash, MergeMethodRebase)
+}
+
+// ReviewDecision returns the review decision of the PR based on the repo's required reviews.
+// An empty decision means reviews are not required.
+// Reference: https://docs.github.com/en/graphql/reference/objects#pullrequest
+func (c *Client) ReviewDecision(repo string, number int) (string, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid repo: %s", repo)
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!) {
+		repository(owner: $owner, name: $name) { pullRequest(number: $number) { reviewDecision } }
+	}`
+	var data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewDecision string `json:"reviewDecision"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	variables := map[string]interface{}{"owner": parts[0], "name": parts[1], "number": number}
+	if err := c.graphQL(query, variables, &data); err != nil {
+		return "", err
+	}
+	return data.Repository.PullRequest.ReviewDecision, nil
+}
+
+// MergePullRequest merges the PR with the method. The merge fails if the head has changed since sha.
+// Reference: https://docs.github.com/en/rest/reference/pulls#merge-a-pull-request
+func (c *Client) MergePullRequest(repo string, pr *PullRequestResult, method, commitTitle string) error {
+	reqBody := map[string]string{
+		"merge_method": method,
+		"sha":          pr.Head.SHA,
+	}
+	if commitTitle != "" {
+		reqBody["commit_title"] = commitTitle
+	}
+
+	var result struct {
+		Merged  bool   `json:"merged"`
+		Message string `json:"message"`
+	}
+	if err := c.do("PUT", fmt.Sprintf("repos/%s/pulls/%d/merge", repo, pr.Number), reqBody, &result); err != nil {
+		return err
+	}
+	if !result.Merged {
+		return fmt.Errorf("pull request #%d was not merged: %s", pr.Number, result.Message)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PullRequestResult is the data structure for a PR from GitHub's JSON API response.
+type PullRequestResult struct {
+	Number   int        `json:"number"`
+	NodeID   string     `json:"node_id"`
+	URL      string     `json:"html_url"`
+	State    string     `json:"state"`
+	Title    string     `json:"title"`
+	Body     string     `json:"body"`
+	Draft    bool       `json:"draft"`
+	MergedAt *time.Time `json:"merged_at"`
+	Head     struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// IsMerged returns true if the PR has been merged.
+func (r *PullRequestResult) IsMerged() bool {
+	return r.MergedAt != nil
+}
+
+// CreatePullRequest creates the PR in the repo ("owner/name"), requests its reviewers
+// and sets its assignees, labels and milestone.
+// Reference: https://docs.github.com/en/rest/reference/pulls#create-a-pull-request
+func (c *Client) CreatePullRequest(repo string, p PullRequest) (*PullRequestResult, error) {
+	reqBody := map[string]interface{}{
+		"title": p.Title,
+		"head":  p.Head,
+		"base":  p.Base,
+		"body":  p.Body,
+		"draft": p.Draft,
+	}
+
+	var result PullRequestResult
+	err := c.do("POST", fmt.Sprintf("repos/%s/pulls", repo), reqBody, &result)
+	if isAlreadyExistsErr(err) {
+		return nil, ErrPullRequestExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.RequestReviewers(repo, result.Number, p.Reviewers, p.TeamReviewers); err != nil {
+		return &result, err
+	}
+	if err := c.updateIssueFields(repo, result.Number, p); err != nil {
+		return &result, err
+	}
+	return &result, nil
+}
+
+// RequestReviewers on the PR. Team reviewers are given in the "org/team" or "team" form.
+// Reference: https://docs.github.com/en/rest/reference/pulls#request-reviewers-for-a-pull-request
+func (c *Client) RequestReviewers(repo string, number int, users, teams []string) error {
+	if len(users) == 0 && len(teams) == 0 {
+		return nil
+	}
+
+	slugs := []string{}
+	for _, t := range teams {
+		slugs = append(slugs, t[strings.Index(t, "/")+1:])
+	}
+	if users == nil {
+		users = []string{}
+	}
+
+	reqBody := map[string][]string{"reviewers": users, "team_reviewers": slugs}
+	return c.do("POST", fmt.Sprintf("repos/%s/pulls/%d/requested_reviewers", repo, number), reqBody, nil)
+}
+
+// updateIssueFields sets the assignees, labels and milestone of the PR.
+// Reference: https://docs.github.com/en/rest/reference/issues#update-an-issue
+func (c *Client) updateIssueFields(repo string, number int, p PullRequest) error {
+	reqBody := map[string]interface{}{}
+	if len(p.Assignees) > 0 {
+		reqBody["assignees"] = p.Assignees
+	}
+	if len(p.Labels) > 0 {
+		reqBody["labels"] = p.Labels
+	}
+	if p.Milestone != "" {
+		milestone, err := c.findMilestone(repo, p.Milestone)
+		if err != nil {
+			return err
+		}
+		reqBody["milestone"] = milestone
+	}
+	if len(reqBody) == 0 {
+		return nil
+	}
+	return c.do("PATCH", fmt.Sprintf("repos/%s/issues/%d", repo, number), reqBody, nil)
+}
+
+// findMilestone returns the number of the open milestone with the title or number.
+// Reference: https://docs.github.com/en/rest/reference/issues#list-milestones
+func (c *Client) findMilestone(repo, title string) (int, error) {
+	var milestones []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	if err := c.do("GET", fmt.Sprintf("repos/%s/milestones?state=open&per_page=100", repo), nil, &milestones); err != nil {
+		return 0, err
+	}
+	for _, m := range milestones {
+		if strings.EqualFold(m.Title, title) || strconv.Itoa(m.Number) == title {
+			return m.Number, nil
+		}
+	}
+	return 0, fmt.Errorf("milestone not found: %s", title)
+}
+
+// FindPullRequest returns the most recent PR for the branch in the repo ("owner/name").
+// Reference: https://docs.github.com/en/rest/reference/pulls#list-pull-requests
+func (c *Client) FindPullRequest(repo, branch string) (*PullRequestResult, error) {
+	owner := strings.SplitN(repo, "/", 2)[0]
+	q := url.Values{}
+	q.Set("head", owner+":"+branch)
+	q.Set("state", "all")
+
+	var results []PullRequestResult
+	if err := c.do("GET", fmt.Sprintf("repos/%s/pulls?%s", repo, q.Encode()), nil, &results); err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+	return &results[0], nil
+}
+
+// FindOpenPullRequest returns the open PR for the branch in the repo ("owner/name"), or nil if there is none.
+func (c *Client) FindOpenPullRequest(repo, branch string) (*PullRequestResult, error) {
+	pr, err := c.FindPullRequest(repo, branch)
+	if err == ErrPullRequestNotFound || (err == nil && pr.State != "open") {
+		return nil, nil
+	}
+	return pr, err
+}
+
+// UpdatePullRequest sets the title and body of the PR. Empty values are left unchanged.
+// Reference: https://docs.github.com/en/rest/reference/pulls#update-a-pull-request
+func (c *Client) UpdatePullRequest(repo string, number int, title, body string) error {
+	reqBody := map[string]string{}
+	if title != "" {
+		reqBody["title"] = title
+	}
+	if body != "" {
+		reqBody["body"] = body
+	}
+	if len(reqBody) == 0 {
+		return nil
+	}
+	return c.do("PATCH", fmt.Sprintf("repos/%s/pulls/%d", repo, number), reqBody, nil)
+}
+
+// SetBase changes the base branch of the PR.
+func (c *Client) SetBase(repo string, number int, base string) error {
+	reqBody := map[string]string{"base": base}
+	return c.do("PATCH", fmt.Sprintf("repos/%s/pulls/%d", repo, number), reqBody, nil)
+}
+
+// SetDraft converts the PR to a draft or marks it ready for review.
+// The REST API cannot change the draft state so this uses the GraphQL API.
+// Reference: https://docs.github.com/en/graphql/reference/mutations#markpullrequestreadyforreview
+func (c *Client) SetDraft(pr *PullRequestResult, draft bool) error {
+	mutation := "markPullRequestReadyForReview"
+	if draft {
+		mutation = "convertPullRequestToDraft"
+	}
+	query := fmt.Sprintf(`mutation($id: ID!) { %s(input: {pullRequestId: $id}) { pullRequest { isDraft } } }`, mutation)
+	if err := c.graphQL(query, map[string]interface{}{"id": pr.NodeID}, nil); err != nil {
+		return err
+	}
+	pr.Draft = draft
+	return nil
+}
+
+// isAlreadyExistsErr returns true if the API rejected a new PR because one exists.
+func isAlreadyExistsErr(err error) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == 422 && strings.Contains(e.Error(), "already exists")
+}
+
+// SplitReviewers splits the comma separated list of reviewers into users and "org/team" teams.
+func SplitReviewers(list string) (users, teams []string) {
+	for _, r := range splitList(list) {
+		if strings.Contains(r, "/") {
+			teams = append(teams, r)
+		} else {
+			users = append(users, r)
+		}
+	}
+	return users, teams
+}
+
+// splitList splits a comma separated list, ignoring blank items.
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
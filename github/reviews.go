@@ -0,0 +1,191 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Review states.
+const (
+	ReviewStateApproved         = "APPROVED"
+	ReviewStateChangesRequested = "CHANGES_REQUESTED"
+	ReviewStateCommented        = "COMMENTED"
+)
+
+// Review is a PR review submitted by a user.
+type Review struct {
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// ReviewThread is a thread of review comments on a line of a file.
+type ReviewThread struct {
+	Path     string
+	Line     int
+	Outdated bool
+	Comments []ReviewComment
+}
+
+// ReviewComment is a comment in a review thread.
+type ReviewComment struct {
+	Author string
+	Body   string
+}
+
+// LatestReviews returns the review of each user that decides their review state, in the order submitted.
+// Comments do not replace an earlier approval or request for changes, like on GitHub.
+func LatestReviews(reviews []Review) []Review {
+	var order []string
+	latest := make(map[string]Review)
+	for _, r := range reviews {
+		login := r.User.Login
+		current, seen := latest[login]
+		if !seen {
+			order = append(order, login)
+		}
+		if seen && r.State == ReviewStateCommented && current.State != ReviewStateCommented {
+			continue
+		}
+		latest[login] = r
+	}
+
+	var result []Review
+	for _, login := range order {
+		result = append(result, latest[login])
+	}
+	return result
+}
+
+// Reviews returns the reviews submitted on the PR, following all pages.
+// Reference: https://docs.github.com/en/rest/reference/pulls#list-reviews-for-a-pull-request
+func (c *Client) Reviews(repo string, number int) ([]Review, error) {
+	var reviews []Review
+	err := c.getPages(fmt.Sprintf("repos/%s/pulls/%d/reviews?per_page=100", repo, number), func(page []byte) error {
+		var r []Review
+		if err := json.Unmarshal(page, &r); err != nil {
+			return err
+		}
+		reviews = append(reviews, r...)
+		return nil
+	})
+	return reviews, err
+}
+
+// reviewCommentConnection is a page of review comments from GitHub's GraphQL API response.
+type reviewCommentConnection struct {
+	PageInfo pageInfo `json:"pageInfo"`
+	Nodes    []struct {
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Body string `json:"body"`
+	} `json:"nodes"`
+}
+
+// reviewCommentFields are the GraphQL fields of a page of review comments.
+const reviewCommentFields = `pageInfo { hasNextPage endCursor } nodes { author { login } body }`
+
+// UnresolvedReviewThreads returns the review threads of the PR which have not been resolved.
+// All pages of threads and comments are fetched.
+// The REST API does not expose whether a thread is resolved so this uses the GraphQL API.
+// Reference: https://docs.github.com/en/graphql/reference/objects#pullrequestreviewthread
+func (c *Client) UnresolvedReviewThreads(repo string, number int) ([]ReviewThread, error) {
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid repo: %s", repo)
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+		repository(owner: $owner, name: $name) {
+			pullRequest(number: $number) {
+				reviewThreads(first: 100, after: $after) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						id
+						isResolved
+						isOutdated
+						path
+						line
+						originalLine
+						comments(first: 100) { ` + reviewCommentFields + ` }
+					}
+				}
+			}
+		}
+	}`
+	var data struct {
+		Repository struct {
+			PullRequest struct {
+				ReviewThreads struct {
+					PageInfo pageInfo `json:"pageInfo"`
+					Nodes    []struct {
+						ID           string                  `json:"id"`
+						IsResolved   bool                    `json:"isResolved"`
+						IsOutdated   bool                    `json:"isOutdated"`
+						Path         string                  `json:"path"`
+						Line         int                     `json:"line"`
+						OriginalLine int                     `json:"originalLine"`
+						Comments     reviewCommentConnection `json:"comments"`
+					} `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+
+	var threads []ReviewThread
+	variables := map[string]interface{}{"owner": parts[0], "name": parts[1], "number": number, "after": nil}
+	for {
+		if err := c.graphQL(query, variables, &data); err != nil {
+			return nil, err
+		}
+
+		for _, n := range data.Repository.PullRequest.ReviewThreads.Nodes {
+			if n.IsResolved {
+				continue
+			}
+			t := ReviewThread{Path: n.Path, Line: n.Line, Outdated: n.IsOutdated}
+			if t.Line == 0 {
+				t.Line = n.OriginalLine
+			}
+			comments := n.Comments
+			for {
+				for _, comment := range comments.Nodes {
+					t.Comments = append(t.Comments, ReviewComment{Author: comment.Author.Login, Body: comment.Body})
+				}
+				if !comments.PageInfo.HasNextPage {
+					break
+				}
+				var err error
+				if comments, err = c.reviewThreadComments(n.ID, comments.PageInfo.EndCursor); err != nil {
+					return nil, err
+				}
+			}
+			threads = append(threads, t)
+		}
+
+		page := data.Repository.PullRequest.ReviewThreads.PageInfo
+		if !page.HasNextPage {
+			return threads, nil
+		}
+		variables["after"] = page.EndCursor
+	}
+}
+
+// reviewThreadComments returns the page of comments of the review thread after the cursor.
+func (c *Client) reviewThreadComments(threadID, after string) (reviewCommentConnection, error) {
+	query := `query($id: ID!, $after: String) {
+		node(id: $id) { ... on PullRequestReviewThread { comments(first: 100, after: $after) { ` + reviewCommentFields + ` } } }
+	}`
+	var data struct {
+		Node struct {
+			Comments reviewCommentConnection `json:"comments"`
+		} `json:"node"`
+	}
+	err := c.graphQL(query, map[string]interface{}{"id": threadID, "after": after}, &data)
+	return data.Node.Comments, err
+}
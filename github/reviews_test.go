@@ -0,0 +1,91 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestReviewsFollowsPages(t *testing.T) {
+	var serverURL string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`[{"user": {"login": "bob"}, "state": "APPROVED"}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/pulls/7/reviews?per_page=100&page=2>; rel="next", <%[1]s/repos/o/r/pulls/7/reviews?per_page=100&page=2>; rel="last"`, serverURL))
+		w.Write([]byte(`[{"user": {"login": "alice"}, "state": "CHANGES_REQUESTED"}]`))
+	})
+	serverURL = c.BaseURL
+
+	reviews, err := c.Reviews("o/r", 7)
+	if err != nil {
+		t.Fatalf("Reviews() error = %v", err)
+	}
+	var got []string
+	for _, r := range reviews {
+		got = append(got, r.User.Login+":"+r.State)
+	}
+	want := []string{"alice:CHANGES_REQUESTED", "bob:APPROVED"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Reviews() = %v, want %v", got, want)
+	}
+}
+
+func TestUnresolvedReviewThreadsFollowsPages(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		decodeBody(t, r, &req)
+		switch {
+		case req.Variables["id"] == "T2":
+			w.Write([]byte(`{"data": {"node": {"comments": {"pageInfo": {"hasNextPage": false}, "nodes": [{"author": {"login": "bob"}, "body": "second"}]}}}}`))
+		case req.Variables["after"] == nil:
+			w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "C1"},
+				"nodes": [{"id": "T1", "isResolved": true, "path": "a.go", "line": 1, "comments": {"pageInfo": {"hasNextPage": false}, "nodes": []}}]
+			}}}}}`))
+		case req.Variables["after"] == "C1":
+			w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": false, "endCursor": "C2"},
+				"nodes": [{"id": "T2", "isResolved": false, "path": "b.go", "line": 0, "originalLine": 4, "comments": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "D1"},
+					"nodes": [{"author": {"login": "alice"}, "body": "first"}]
+				}}]
+			}}}}}`))
+		default:
+			t.Errorf("unexpected variables %v", req.Variables)
+		}
+	})
+
+	threads, err := c.UnresolvedReviewThreads("o/r", 7)
+	if err != nil {
+		t.Fatalf("UnresolvedReviewThreads() error = %v", err)
+	}
+	want := []ReviewThread{{
+		Path:     "b.go",
+		Line:     4,
+		Comments: []ReviewComment{{Author: "alice", Body: "first"}, {Author: "bob", Body: "second"}},
+	}}
+	if !reflect.DeepEqual(threads, want) {
+		t.Errorf("UnresolvedReviewThreads() = %+v, want %+v", threads, want)
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{"", ""},
+		{`<https://api.github.com/x?page=2>; rel="next", <https://api.github.com/x?page=5>; rel="last"`, "https://api.github.com/x?page=2"},
+		{`<https://api.github.com/x?page=1>; rel="prev", <https://api.github.com/x?page=1>; rel="first"`, ""},
+	}
+	for _, tt := range tests {
+		if got := nextPageURL(tt.link); got != tt.want {
+			t.Errorf("nextPageURL(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
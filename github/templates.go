@@ -0,0 +1,88 @@
+package github
+
+import (
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// templateName is the file and directory name of PR templates, without the extension.
+const templateName = "pull_request_template"
+
+// templateDirs are the directories, relative to the project root, that GitHub searches for PR templates.
+// Reference: https://docs.github.com/en/communities/using-templates-to-encourage-useful-issues-and-pull-requests/creating-a-pull-request-template-for-your-repository
+var templateDirs = []string{"", ".github", "docs"}
+
+// templateNamesByCategory are the template file names, without the extension, that match each issue category.
+var templateNamesByCategory = map[string][]string{
+	issues.StoryCategory: {"feature", "story", "enhancement"},
+	issues.BugCategory:   {"bug", "bugfix", "fix"},
+	issues.TaskCategory:  {"task", "chore"},
+}
+
+// FindTemplates returns the paths of all the PR templates in the project root directory.
+// This includes single templates and the templates in PULL_REQUEST_TEMPLATE directories,
+// regardless of the case of their names.
+func FindTemplates(root string) []string {
+	var templates []string
+	for _, dir := range templateDirs {
+		entries, err := ioutil.ReadDir(path.Join(root, dir))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			p := path.Join(root, dir, e.Name())
+			switch {
+			case !e.IsDir() && strings.EqualFold(e.Name(), templateName+".md"):
+				templates = append(templates, p)
+			case e.IsDir() && strings.EqualFold(e.Name(), templateName):
+				templates = append(templates, markdownFiles(p)...)
+			}
+		}
+	}
+	return templates
+}
+
+// markdownFiles returns the paths of the markdown files in the directory.
+func markdownFiles(dir string) []string {
+	var files []string
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return files
+	}
+	for _, e := range entries {
+		if !e.IsDir() && strings.EqualFold(filepath.Ext(e.Name()), ".md") {
+			files = append(files, path.Join(dir, e.Name()))
+		}
+	}
+	return files
+}
+
+// TemplateForIssue returns the template whose name matches the issue type. Example: Bug → bug.md
+// Returns an empty string if none match.
+func TemplateForIssue(templates []string, issue issues.Issue) string {
+	names := append([]string{strings.ToLower(issue.Type)}, templateNamesByCategory[issue.Category()]...)
+	for _, name := range names {
+		if t := FindTemplate(templates, name); t != "" {
+			return t
+		}
+	}
+	return ""
+}
+
+// FindTemplate returns the template matching the name, with or without the extension, or the path.
+// Returns an empty string if none match.
+func FindTemplate(templates []string, name string) string {
+	for _, t := range templates {
+		base := filepath.Base(t)
+		if strings.EqualFold(base, name) ||
+			strings.EqualFold(strings.TrimSuffix(base, filepath.Ext(base)), name) ||
+			strings.HasSuffix(t, "/"+strings.TrimPrefix(name, "./")) {
+			return t
+		}
+	}
+	return ""
+}
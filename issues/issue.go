@@ -9,6 +9,23 @@ import (
 
 const branchNameMaxLength = "%.40s"
 
+// Issue categories. Every issue type falls into one of these.
+const (
+	StoryCategory = "Story"
+	BugCategory   = "Bug"
+	TaskCategory  = "Task"
+)
+
+// Categories in the order they should be displayed.
+var Categories = []string{StoryCategory, BugCategory, TaskCategory}
+
+// mergeCommitRe matches the branch in GitHub and git merge commit subjects.
+var mergeCommitRe = regexp.MustCompile(`^Merge (?:pull request #\d+ from [^/\s]+/|branch ')([^'\s]+)`)
+
+// commitIDRe matches a leading issue ID or a Conventional Commits scope issue ID in a commit subject.
+// Examples: "ABC-123: Title" and "feat(ABC-123): Title"
+var commitIDRe = regexp.MustCompile(`^(?:[a-z]+\(([A-Z][A-Z0-9]*-\d+)\)!?:|([A-Z][A-Z0-9]*-\d+)\b)`)
+
 // Issue contains the issue information.
 type Issue struct {
 	ID       string
@@ -18,6 +35,7 @@ type Issue struct {
 	APIURL   string
 	WebURL   string
 	Assignee string
+	Labels   []string
 }
 
 // String representation of an issue.
@@ -46,12 +64,24 @@ func (i Issue) BranchName() string {
 	return strings.TrimSuffix(shortName, "-")
 }
 
-// BranchPrefix returns the Git flow branch prefixes based on the Issue type.
-func (i Issue) branchPrefix() string {
+// Category returns the Story, Bug or Task category of the Issue type.
+func (i Issue) Category() string {
 	switch i.Type {
 	case "Story":
-		return "feature-"
+		return StoryCategory
 	case "Bug":
+		return BugCategory
+	default:
+		return TaskCategory
+	}
+}
+
+// BranchPrefix returns the Git flow branch prefixes based on the Issue type.
+func (i Issue) branchPrefix() string {
+	switch i.Category() {
+	case StoryCategory:
+		return "feature-"
+	case BugCategory:
 		return "bug-"
 	default:
 		return "task-"
@@ -71,3 +101,15 @@ func ParseIDFromBranch(branch string) string {
 	}
 	return ""
 }
+
+// ParseIDFromCommit gets the Issue ID from a commit subject.
+// Merge commits are parsed by their branch name and other commits by a leading or scope ID.
+func ParseIDFromCommit(subject string) string {
+	if m := mergeCommitRe.FindStringSubmatch(subject); m != nil {
+		return strings.ToUpper(ParseIDFromBranch(m[1]))
+	}
+	if m := commitIDRe.FindStringSubmatch(subject); m != nil {
+		return m[1] + m[2]
+	}
+	return ""
+}
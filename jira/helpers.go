@@ -20,6 +20,8 @@ const (
 	TokenConfigKey    = "jira.token"
 	APIConfigKey      = "jira.api_url"
 	WebConfigKey      = "jira.api_url"
+
+	DoneTransitionConfigKey = "jira.done_transition"
 )
 
 // URLs.
@@ -51,6 +53,10 @@ type Config struct {
 	Token     string
 	APIURL    string
 	WebURL    string
+
+	// DoneTransition is the name of the transition that completes an issue.
+	// Defaults to "Done" when empty.
+	DoneTransition string
 }
 
 // errorResponse is the data structure for an error response from Jira's JSON API.
@@ -3,6 +3,7 @@ package jira
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -31,6 +32,8 @@ type issueResponse struct {
 			Name string `json:"name"`
 		} `json:"priority"`
 		Assignee user `json:"assignee"`
+		// The labels of the issue.
+		Labels []string `json:"labels"`
 	} `json:"fields"`
 }
 
@@ -61,13 +64,53 @@ func GetIssue(issueID string, c *Config) (issues.Issue, error) {
 		return i, errors.Wrap(err, "decode failed")
 	}
 
+	return data.toIssue(c), nil
+}
+
+// maxConcurrentRequests is the maximum number of issues retrieved at the same time.
+const maxConcurrentRequests = 10
+
+// GetIssues returns the Jira issues, in the order of the IDs, by retrieving them concurrently.
+// Issues that cannot be retrieved are left out and their errors returned.
+func GetIssues(IDs []string, c *Config) ([]issues.Issue, []error) {
+	results := make([]issues.Issue, len(IDs))
+	errs := make([]error, len(IDs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentRequests)
+	for i, ID := range IDs {
+		wg.Add(1)
+		go func(i int, ID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i], errs[i] = GetIssue(ID, c)
+		}(i, ID)
+	}
+	wg.Wait()
+
+	var list []issues.Issue
+	var failures []error
+	for i := range IDs {
+		if errs[i] != nil {
+			failures = append(failures, errors.Wrapf(errs[i], "get issue %s failed", IDs[i]))
+			continue
+		}
+		list = append(list, results[i])
+	}
+	return list, failures
+}
+
+// toIssue converts the API response to an Issue.
+func (data issueResponse) toIssue(c *Config) issues.Issue {
 	return issues.Issue{
 		ID:       data.Key,
 		Title:    data.Fields.Summary,
 		Type:     data.Fields.IssueType.Name,
 		Status:   data.Fields.Status.Name,
 		Assignee: data.Fields.Assignee.Name,
+		Labels:   data.Fields.Labels,
 		APIURL:   data.Self,
-		WebURL:   joinURLPath(c.WebURL, WebIssuePath, issueID),
-	}, nil
+		WebURL:   joinURLPath(c.WebURL, WebIssuePath, data.Key),
+	}
 }
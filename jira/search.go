@@ -0,0 +1,78 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/greganswer/workflow/issues"
+)
+
+// APISearchPath is the path of the JQL search endpoint.
+const APISearchPath = "/rest/api/3/search"
+
+// searchPageSize is the number of issues requested per page.
+const searchPageSize = 100
+
+// searchResponse is the data structure for a page of Jira's JQL search API response.
+type searchResponse struct {
+	StartAt    int             `json:"startAt"`
+	MaxResults int             `json:"maxResults"`
+	Total      int             `json:"total"`
+	Issues     []issueResponse `json:"issues"`
+}
+
+// GetIssuesByFixVersion returns all the issues in a Jira fix version.
+func GetIssuesByFixVersion(version string, c *Config) ([]issues.Issue, error) {
+	fmt.Printf("Retrieving Jira issues for fix version %s...\n", version)
+	return searchIssues(fmt.Sprintf("fixVersion = %q ORDER BY key ASC", version), c)
+}
+
+// searchIssues returns every issue matching the JQL query, following pagination.
+// Reference: https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issue-search
+func searchIssues(jql string, c *Config) ([]issues.Issue, error) {
+	var result []issues.Issue
+	for startAt := 0; ; {
+		q := url.Values{}
+		q.Set("jql", jql)
+		q.Set("fields", "summary,issuetype,status,priority,assignee,labels")
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", strconv.Itoa(searchPageSize))
+		URL := joinURLPath(c.APIURL, APISearchPath) + "?" + q.Encode()
+
+		res, err := makeRequest("GET", URL, nil, c)
+		if err != nil {
+			return nil, errors.Wrap(err, "makeRequest failed")
+		}
+
+		body, err := readBody(res.Body)
+		if err != nil {
+			return nil, errors.Wrap(err, "read failed")
+		}
+
+		if !statusSuccess(res) {
+			var e errorResponse
+			if err = json.Unmarshal(body, &e); err != nil {
+				return nil, errors.Wrap(err, "decode failed")
+			}
+			return nil, fmt.Errorf("search failed with %s HTTP status: %s", res.Status, e.Messages)
+		}
+
+		var page searchResponse
+		if err = json.Unmarshal(body, &page); err != nil {
+			return nil, errors.Wrap(err, "decode failed")
+		}
+
+		for _, data := range page.Issues {
+			result = append(result, data.toIssue(c))
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return result, nil
+		}
+	}
+}
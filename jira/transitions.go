@@ -14,6 +14,7 @@ import (
 const (
 	inProgress = "In Progress"
 	codeReview = "Code Review"
+	done       = "Done"
 )
 
 // Transitions is the data model for the transition API response.
@@ -49,6 +50,20 @@ func TransitionToCodeReview(issue issues.Issue, c *Config) error {
 	return transitionIssue(codeReview, issue, c)
 }
 
+// TransitionToDone updates the status Jira issue to the configured done transition.
+func TransitionToDone(issue issues.Issue, c *Config) error {
+	name := c.DoneTransition
+	if name == "" {
+		name = done
+	}
+	return transitionIssue(name, issue, c)
+}
+
+// IsInCodeReview returns true if the Jira issue has the "Code Review" status.
+func IsInCodeReview(issue issues.Issue) bool {
+	return issue.Status == codeReview
+}
+
 func transitionIssue(name string, issue issues.Issue, c *Config) error {
 	if issue.Status == name {
 		fmt.Printf("Jira issue %s status already set to '%s'\n", issue.ID, name)